@@ -0,0 +1,8 @@
+// Package api embeds sample OneProvider API responses.
+// They seed the fake server from the oneprovidertest package.
+package api
+
+import "embed"
+
+//go:embed *.json
+var Fixtures embed.FS
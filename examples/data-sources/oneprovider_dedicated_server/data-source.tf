@@ -0,0 +1,13 @@
+terraform {
+  required_providers {
+    oneprovider = {
+      source = "registry.terraform.io/MadJlzz/oneprovider"
+    }
+  }
+}
+
+provider "oneprovider" {}
+
+data "oneprovider_dedicated_server" "db" {
+  hostname = "db-1.example.com"
+}
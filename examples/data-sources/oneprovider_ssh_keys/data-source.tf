@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    oneprovider = {
+      source = "registry.terraform.io/MadJlzz/oneprovider"
+    }
+  }
+}
+
+provider "oneprovider" {}
+
+data "oneprovider_ssh_keys" "team" {
+  name_prefix = "team-"
+}
+
+resource "oneprovider_vm_instance" "web" {
+  location_id      = "33"
+  instance_size_id = "45"
+  template_id      = "1194"
+  hostname         = "web"
+  ssh_keys         = data.oneprovider_ssh_keys.team.ids
+}
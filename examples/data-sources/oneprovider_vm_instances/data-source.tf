@@ -0,0 +1,15 @@
+terraform {
+  required_providers {
+    oneprovider = {
+      source = "registry.terraform.io/MadJlzz/oneprovider"
+    }
+  }
+}
+
+provider "oneprovider" {}
+
+data "oneprovider_vm_instances" "web" {
+  hostname_regex = "^web-"
+  city           = "Brussels"
+  state          = "online"
+}
@@ -0,0 +1,20 @@
+terraform {
+  required_providers {
+    oneprovider = {
+      source = "registry.terraform.io/MadJlzz/oneprovider"
+    }
+  }
+}
+
+provider "oneprovider" {}
+
+data "oneprovider_vm_size" "dev" {
+  name = "01d20c1-2"
+}
+
+# Any European location offering the size with IPv6.
+data "oneprovider_vm_locations" "europe" {
+  region  = "Europe"
+  size_id = data.oneprovider_vm_size.dev.id
+  ipv6    = true
+}
@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    oneprovider = {
+      source = "registry.terraform.io/MadJlzz/oneprovider"
+    }
+  }
+}
+
+provider "oneprovider" {}
+
+data "oneprovider_vm_location" "brussels" {
+  city = "Brussels"
+}
+
+# Smallest size with at least 2 cores and 4 GB of RAM available in Brussels.
+data "oneprovider_vm_sizes" "app" {
+  location_id = data.oneprovider_vm_location.brussels.id
+  min_cores   = 2
+  min_ram_mb  = 4096
+  smallest    = true
+}
@@ -0,0 +1,14 @@
+terraform {
+  required_providers {
+    oneprovider = {
+      source = "registry.terraform.io/MadJlzz/oneprovider"
+    }
+  }
+}
+
+provider "oneprovider" {}
+
+data "oneprovider_vm_snapshots" "golden" {
+  name_regex = "^golden-"
+  status     = "available"
+}
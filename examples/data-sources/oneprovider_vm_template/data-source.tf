@@ -11,3 +11,8 @@ provider "oneprovider" {}
 data "oneprovider_vm_template" "ubuntu" {
   name = "Ubuntu 24.04 64bits"
 }
+
+data "oneprovider_vm_template" "latest_debian" {
+  family      = "debian"
+  most_recent = true
+}
@@ -0,0 +1,14 @@
+terraform {
+  required_providers {
+    oneprovider = {
+      source = "registry.terraform.io/MadJlzz/oneprovider"
+    }
+  }
+}
+
+provider "oneprovider" {}
+
+data "oneprovider_vm_templates" "ubuntu_lts" {
+  family  = "ubuntu"
+  version = ">= 22.04"
+}
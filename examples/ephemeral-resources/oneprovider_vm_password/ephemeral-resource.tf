@@ -0,0 +1,40 @@
+terraform {
+  required_providers {
+    oneprovider = {
+      source = "registry.terraform.io/MadJlzz/oneprovider"
+    }
+    vault = {
+      source = "hashicorp/vault"
+    }
+  }
+}
+
+provider "oneprovider" {}
+
+resource "oneprovider_vm_instance" "vm" {
+  location_id      = "33"
+  instance_size_id = "45"
+  template_id      = "1194"
+  hostname         = "theOneRing"
+  store_password   = false
+}
+
+# Only rotate when asked to, e.g. with `terraform apply -var rotate_root_password=true`.
+# Every plan or apply run with it set to true resets the root password of the VM instance.
+variable "rotate_root_password" {
+  type    = bool
+  default = false
+}
+
+ephemeral "oneprovider_vm_password" "root" {
+  vm_id  = oneprovider_vm_instance.vm.id
+  rotate = var.rotate_root_password
+}
+
+# Bump data_json_wo_version with each rotation so that the new password is written.
+resource "vault_kv_secret_v2" "root" {
+  mount                = "secret"
+  name                 = "vm/${oneprovider_vm_instance.vm.hostname}"
+  data_json_wo         = jsonencode({ password = ephemeral.oneprovider_vm_password.root.password })
+  data_json_wo_version = 1
+}
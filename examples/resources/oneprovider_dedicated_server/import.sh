@@ -0,0 +1 @@
+terraform import oneprovider_dedicated_server.db "id"
@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    oneprovider = {
+      source = "registry.terraform.io/MadJlzz/oneprovider"
+    }
+  }
+}
+
+provider "oneprovider" {}
+
+data "oneprovider_dedicated_server" "db" {
+  hostname = "db-1.example.com"
+}
+
+resource "oneprovider_ssh_key" "admin" {
+  name       = "admin"
+  public_key = file("~/.ssh/id_ed25519.pub")
+}
+
+# Adopt the server ordered from the OneProvider panel, and reinstall it with another OS.
+resource "oneprovider_dedicated_server" "db" {
+  server_id = data.oneprovider_dedicated_server.db.id
+  hostname  = "db-1.example.com"
+  os_id     = "25"
+  ssh_keys  = [oneprovider_ssh_key.admin.id]
+}
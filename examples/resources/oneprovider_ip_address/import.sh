@@ -0,0 +1 @@
+terraform import oneprovider_ip_address.service "1234"
@@ -0,0 +1,14 @@
+terraform {
+  required_providers {
+    oneprovider = {
+      source = "registry.terraform.io/MadJlzz/oneprovider"
+    }
+  }
+}
+
+provider "oneprovider" {}
+
+resource "oneprovider_ip_address" "service" {
+  location_id = "33"
+  type        = "ipv4"
+}
@@ -0,0 +1 @@
+terraform import oneprovider_ip_assignment.service "1234"
@@ -0,0 +1,33 @@
+terraform {
+  required_providers {
+    oneprovider = {
+      source = "registry.terraform.io/MadJlzz/oneprovider"
+    }
+  }
+}
+
+provider "oneprovider" {}
+
+resource "oneprovider_vm_instance" "blue" {
+  location_id      = "33"
+  instance_size_id = "45"
+  template_id      = "1194"
+  hostname         = "blue"
+}
+
+resource "oneprovider_vm_instance" "green" {
+  location_id      = "33"
+  instance_size_id = "45"
+  template_id      = "1194"
+  hostname         = "green"
+}
+
+resource "oneprovider_ip_address" "service" {
+  location_id = "33"
+}
+
+# Switch vm_id to the green instance to move the service IP address.
+resource "oneprovider_ip_assignment" "service" {
+  ip_id = oneprovider_ip_address.service.id
+  vm_id = oneprovider_vm_instance.blue.id
+}
@@ -0,0 +1 @@
+terraform import oneprovider_reverse_dns.mail "192.0.2.10"
@@ -0,0 +1,21 @@
+terraform {
+  required_providers {
+    oneprovider = {
+      source = "registry.terraform.io/MadJlzz/oneprovider"
+    }
+  }
+}
+
+provider "oneprovider" {}
+
+resource "oneprovider_vm_instance" "mail" {
+  location_id      = "33"
+  instance_size_id = "45"
+  template_id      = "1194"
+  hostname         = "mail"
+}
+
+resource "oneprovider_reverse_dns" "mail" {
+  ip_address = oneprovider_vm_instance.mail.ip_address
+  hostname   = "mail.example.com"
+}
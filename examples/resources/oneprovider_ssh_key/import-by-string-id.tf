@@ -0,0 +1,4 @@
+import {
+  to = oneprovider_ssh_key.ubuntu
+  id = "name:example"
+}
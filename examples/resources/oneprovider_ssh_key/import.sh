@@ -1 +1,5 @@
-terraform import oneprovider_ssh_key.ubuntu "uuid"
\ No newline at end of file
+# Import by UUID.
+terraform import oneprovider_ssh_key.ubuntu "uuid"
+
+# Import by name.
+terraform import oneprovider_ssh_key.ubuntu "name:example"
@@ -0,0 +1 @@
+terraform import oneprovider_vm_snapshot.golden "id"
@@ -0,0 +1,29 @@
+terraform {
+  required_providers {
+    oneprovider = {
+      source = "registry.terraform.io/MadJlzz/oneprovider"
+    }
+  }
+}
+
+provider "oneprovider" {}
+
+resource "oneprovider_vm_instance" "golden" {
+  location_id      = "33"
+  instance_size_id = "45"
+  template_id      = "1194"
+  hostname         = "golden"
+}
+
+resource "oneprovider_vm_snapshot" "golden" {
+  vm_id = oneprovider_vm_instance.golden.id
+  name  = "golden-image"
+}
+
+# Clone the golden image.
+resource "oneprovider_vm_instance" "web" {
+  location_id      = "33"
+  instance_size_id = "45"
+  snapshot_id      = oneprovider_vm_snapshot.golden.id
+  hostname         = "web"
+}
@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/client"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure = &dedicatedServerDataSource{}
+)
+
+type dedicatedServerDataSource struct {
+	datasourceServiceInjector
+}
+
+type dedicatedServerDataSourceModel struct {
+	// Input attributes (Optional/Required for filtering)
+	Id       types.String `tfsdk:"id"`
+	Hostname types.String `tfsdk:"hostname"`
+
+	// Output attributes (Computed)
+	IPAddress types.String `tfsdk:"ip_address"`
+	Location  types.String `tfsdk:"location"`
+	Model     types.String `tfsdk:"model"`
+	Cpu       types.String `tfsdk:"cpu"`
+	Ram       types.String `tfsdk:"ram"`
+	Storage   types.String `tfsdk:"storage"`
+	OsId      types.String `tfsdk:"os_id"`
+	Os        types.String `tfsdk:"os"`
+	State     types.String `tfsdk:"state"`
+	Status    types.String `tfsdk:"status"`
+}
+
+func NewDedicatedServerDataSource() datasource.DataSource {
+	return &dedicatedServerDataSource{}
+}
+
+func (ds *dedicatedServerDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dedicated_server"
+}
+
+func (ds *dedicatedServerDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Retrieve a dedicated server of the account by its ID or hostname.",
+		MarkdownDescription: "Retrieve a dedicated server of the account by its ID or hostname.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "Filter by ID.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(
+						path.Expressions{path.MatchRoot("hostname")}...,
+					),
+				},
+			},
+			"hostname": schema.StringAttribute{
+				Description: "Filter by hostname, ignoring case. It must match a single dedicated server.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"ip_address": schema.StringAttribute{
+				Description: "Main IP address of the dedicated server",
+				Computed:    true,
+			},
+			"location": schema.StringAttribute{
+				Description: "City where the dedicated server is hosted",
+				Computed:    true,
+			},
+			"model": schema.StringAttribute{
+				Description: "Hardware model of the dedicated server",
+				Computed:    true,
+			},
+			"cpu": schema.StringAttribute{
+				Description: "CPU of the dedicated server as reported by the API",
+				Computed:    true,
+			},
+			"ram": schema.StringAttribute{
+				Description: "Memory of the dedicated server as reported by the API",
+				Computed:    true,
+			},
+			"storage": schema.StringAttribute{
+				Description: "Disks of the dedicated server as reported by the API",
+				Computed:    true,
+			},
+			"os_id": schema.StringAttribute{
+				Description: "ID of the installed operating system",
+				Computed:    true,
+			},
+			"os": schema.StringAttribute{
+				Description: "Name of the installed operating system",
+				Computed:    true,
+			},
+			"state": schema.StringAttribute{
+				Description: "Server state, either `online` or `offline`",
+				Computed:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the dedicated server as reported by the API",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (ds *dedicatedServerDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *dedicatedServerDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Id.IsNull() {
+		summary, err := ds.svc.Server.GetServerByHostname(ctx, data.Hostname.ValueString())
+		if err != nil {
+			addDedicatedServerReadError(resp, err)
+			return
+		}
+		data.Id = types.StringValue(summary.Id)
+	}
+
+	info, err := ds.svc.Server.GetServerByID(ctx, data.Id.ValueString())
+	if err != nil {
+		addDedicatedServerReadError(resp, err)
+		return
+	}
+	data.Hostname = types.StringValue(info.Response.ServerInfo.Hostname)
+	data.IPAddress = types.StringValue(info.Response.ServerInfo.IpAddress)
+	data.Location = types.StringValue(info.Response.ServerInfo.Location)
+	data.Model = types.StringValue(info.Response.ServerInfo.Model)
+	data.Cpu = types.StringValue(info.Response.ServerInfo.Cpu)
+	data.Ram = types.StringValue(info.Response.ServerInfo.Ram)
+	data.Storage = types.StringValue(info.Response.ServerInfo.Storage)
+	data.OsId = types.StringValue(info.Response.ServerInfo.OsId)
+	data.Os = types.StringValue(info.Response.ServerInfo.Os)
+	data.State = types.StringValue(info.Response.ServerState.State)
+	data.Status = types.StringValue(info.Response.ServerState.Status)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func addDedicatedServerReadError(resp *datasource.ReadResponse, err error) {
+	if errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Dedicated server not found",
+			"No dedicated server matches the given filter.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.AddError(
+		"Unable to refresh datasource",
+		"An unexpected error occurred while creating the datasource read request."+
+			"Please report this issue to the provider developers.\n\n"+
+			err.Error(),
+	)
+}
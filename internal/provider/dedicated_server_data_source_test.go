@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+const testAccDedicatedServerDataSourceConfig = `
+data "oneprovider_dedicated_server" "by_id" {
+	id = "%s"
+}
+data "oneprovider_dedicated_server" "by_hostname" {
+	hostname = data.oneprovider_dedicated_server.by_id.hostname
+}
+`
+
+const testAccDedicatedServerDataSourceNotFoundConfig = `
+data "oneprovider_dedicated_server" "unknown" {hostname = "unknown.example.com"}
+`
+
+func TestAccDedicatedServerDataSource(t *testing.T) {
+	id := testAccDedicatedServerID(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccDedicatedServerDataSourceConfig, id),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.oneprovider_dedicated_server.by_id",
+						tfjsonpath.New("ip_address"),
+						knownvalue.NotNull(),
+					),
+					statecheck.CompareValuePairs(
+						"data.oneprovider_dedicated_server.by_hostname",
+						tfjsonpath.New("id"),
+						"data.oneprovider_dedicated_server.by_id",
+						tfjsonpath.New("id"),
+						compare.ValuesSame(),
+					),
+				},
+			},
+			{
+				Config:      testAccDedicatedServerDataSourceNotFoundConfig,
+				ExpectError: regexp.MustCompile("Dedicated server not found"),
+			},
+		},
+	})
+}
@@ -0,0 +1,452 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/client"
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/server"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+var (
+	_ resource.Resource                = &dedicatedServerResource{}
+	_ resource.ResourceWithConfigure   = &dedicatedServerResource{}
+	_ resource.ResourceWithImportState = &dedicatedServerResource{}
+	_ resource.ResourceWithModifyPlan  = &dedicatedServerResource{}
+)
+
+type dedicatedServerResource struct {
+	resourceServiceInjector
+}
+
+type dedicatedServerResourceModel struct {
+	ID        types.String   `tfsdk:"id"`
+	ServerId  types.String   `tfsdk:"server_id"`
+	Hostname  types.String   `tfsdk:"hostname"`
+	OsId      types.String   `tfsdk:"os_id"`
+	SshKeys   types.List     `tfsdk:"ssh_keys"`
+	IPAddress types.String   `tfsdk:"ip_address"`
+	Location  types.String   `tfsdk:"location"`
+	Model     types.String   `tfsdk:"model"`
+	Os        types.String   `tfsdk:"os"`
+	Password  types.String   `tfsdk:"password"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewDedicatedServerResource() resource.Resource {
+	return &dedicatedServerResource{}
+}
+
+func (r *dedicatedServerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dedicated_server"
+}
+
+func (r *dedicatedServerResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manage a dedicated server ordered out of band. Creating the resource adopts the server, " +
+			"destroying it only removes the server from the state, the server itself is never cancelled.",
+		MarkdownDescription: "Manage a dedicated server ordered out of band. Creating the resource adopts the server, " +
+			"destroying it only removes the server from the state, the server itself is never cancelled.",
+		Attributes: map[string]schema.Attribute{
+			// Inputs
+			"server_id": schema.StringAttribute{
+				Description: "ID of the dedicated server to adopt, e.g. from the `oneprovider_dedicated_server` data source.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"hostname": schema.StringAttribute{
+				Description: "Hostname of the dedicated server. Defaults to the current hostname.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"os_id": schema.StringAttribute{
+				Description: "ID of the operating system of the dedicated server. Defaults to the installed one. " +
+					"Setting another ID reinstalls the server in place, including when it is adopted: all data on its disks is lost and a new root password is generated.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ssh_keys": schema.ListAttribute{
+				Description: "List of SSH keys UUID to install when the operating system is reinstalled. Changing it alone doesn't reinstall the server.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			// Outputs
+			"id": schema.StringAttribute{
+				Description: "ID of the dedicated server",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ip_address": schema.StringAttribute{
+				Description: "Main IP address of the dedicated server",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"location": schema.StringAttribute{
+				Description: "City where the dedicated server is hosted",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"model": schema.StringAttribute{
+				Description: "Hardware model of the dedicated server",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"os": schema.StringAttribute{
+				Description: "Name of the installed operating system",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"password": schema.StringAttribute{
+				Description: "Password of the root user. Only known once the operating system is reinstalled by this resource.",
+				Computed:    true,
+				Sensitive:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+func (r *dedicatedServerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *dedicatedServerResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, err := r.svc.Server.GetServerByID(ctx, data.ServerId.ValueString())
+	if errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("server_id"),
+			"Dedicated server not found",
+			"Dedicated servers must be ordered from OneProvider before being managed.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create resource",
+			"An unexpected error occurred while attempting to read the dedicated server to adopt."+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+	data.ID = data.ServerId
+	data.Password = types.StringNull()
+
+	createTimeout, diags := data.Timeouts.Create(ctx, 30*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current := *data
+	current.setServerInfo(info)
+	reinstalled, diags := r.apply(ctx, &current, data, createTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		// The server is adopted once reinstalled, keep it in the state with its new root password.
+		if reinstalled {
+			resp.Diagnostics.Append(resp.State.Set(ctx, &current)...)
+		}
+		return
+	}
+
+	resp.Diagnostics.Append(r.refresh(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *dedicatedServerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *dedicatedServerResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// During import, only the ID is set.
+	if data.ServerId.IsNull() {
+		data.ServerId = data.ID
+	}
+
+	info, err := r.svc.Server.GetServerByID(ctx, data.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to refresh resource",
+			"An unexpected error occurred while attempting to refresh the resource."+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+	data.setServerInfo(info)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *dedicatedServerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var state *dedicatedServerResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	var plan *dedicatedServerResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, 30*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	reinstalled, diags := r.apply(ctx, state, plan, updateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		// Keep the new OS and root password of a reinstalled server in the state.
+		if reinstalled {
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		}
+		return
+	}
+
+	resp.Diagnostics.Append(r.refresh(ctx, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *dedicatedServerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Dedicated servers are cancelled out of band, the server is only removed from the state.
+	resp.Diagnostics.AddWarning(
+		"Dedicated server not cancelled",
+		"The dedicated server was removed from the Terraform state but keeps running. "+
+			"Cancel it from the OneProvider panel if it is no longer needed.",
+	)
+}
+
+func (r *dedicatedServerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func (r *dedicatedServerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan *dedicatedServerResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The installed OS is only known once the server is adopted.
+	if req.State.Raw.IsNull() {
+		if !plan.OsId.IsUnknown() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("os"), types.StringUnknown())...)
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("password"), types.StringUnknown())...)
+		}
+		return
+	}
+
+	var state *dedicatedServerResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A reinstall changes the OS name and generates a new root password.
+	if !plan.OsId.Equal(state.OsId) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("os"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("password"), types.StringUnknown())...)
+	}
+}
+
+// apply reinstalls and renames the dedicated server when plan differs from current.
+// The password of plan is set when the server is reinstalled, current is then updated with the
+// reinstall so that callers can save it when a later step fails.
+func (r *dedicatedServerResource) apply(ctx context.Context, current, plan *dedicatedServerResourceModel, timeout time.Duration) (reinstalled bool, diags diag.Diagnostics) {
+	// We are reinstalling the dedicated server with another OS...
+	if !plan.OsId.IsUnknown() && !plan.OsId.Equal(current.OsId) {
+		var sshKeys []string
+		diags.Append(plan.SshKeys.ElementsAs(ctx, &sshKeys, false)...)
+		if diags.HasError() {
+			return false, diags
+		}
+
+		reinstall, err := r.svc.Server.ReinstallServer(ctx, &server.ServerReinstallRequest{
+			ServerId: plan.ID.ValueString(),
+			OsId:     plan.OsId.ValueString(),
+			Hostname: plan.Hostname.ValueString(),
+			SshKeys:  sshKeys,
+		})
+		if err != nil {
+			diags.AddError(
+				"Unable to reinstall resource",
+				"An unexpected error occurred while attempting to reinstall the resource."+
+					"Please retry the operation or report this issue to the provider developers.\n\n"+
+					err.Error(),
+			)
+			return false, diags
+		}
+		reinstalled = true
+		plan.Password = types.StringValue(reinstall.Response.Password)
+		current.OsId = plan.OsId
+		current.SshKeys = plan.SshKeys
+		current.Password = plan.Password
+		// The hostname is set by the reinstall.
+		if !plan.Hostname.IsUnknown() {
+			current.Hostname = plan.Hostname
+		}
+
+		err = r.waitForInstall(ctx, plan.ID.ValueString(), timeout)
+		if err != nil {
+			diags.AddError(
+				"Unable to refresh resource after reinstall",
+				"The reinstall was requested but the resource did not become ready."+
+					"Please retry the operation or report this issue to the provider developers.\n\n"+
+					err.Error(),
+			)
+			return reinstalled, diags
+		}
+	}
+
+	// We are updating the hostname...
+	if !plan.Hostname.IsUnknown() && !plan.Hostname.Equal(current.Hostname) {
+		err := r.svc.Server.UpdateServerHostname(ctx, &server.ServerHostnameUpdateRequest{
+			ServerId: plan.ID.ValueString(),
+			Hostname: plan.Hostname.ValueString(),
+		})
+		if err != nil {
+			diags.AddError(
+				"Unable to update resource",
+				"An unexpected error occurred while attempting to update the resource."+
+					"Please retry the operation or report this issue to the provider developers.\n\n"+
+					err.Error(),
+			)
+			return reinstalled, diags
+		}
+
+		err = retry.RetryContext(ctx, 30*time.Second, func() *retry.RetryError {
+			info, infoErr := r.svc.Server.GetServerByID(ctx, plan.ID.ValueString())
+			if infoErr != nil {
+				return retry.NonRetryableError(infoErr)
+			}
+			if info.Response.ServerInfo.Hostname != plan.Hostname.ValueString() {
+				return retry.RetryableError(fmt.Errorf("dedicated server hostname not updated yet"))
+			}
+			return nil
+		})
+		if err != nil {
+			diags.AddError(
+				"Unable to refresh resource after update",
+				"The update succeeded but failed to refresh the resource state."+
+					"Please retry the operation or report this issue to the provider developers.\n\n"+
+					err.Error(),
+			)
+			return reinstalled, diags
+		}
+	}
+
+	return reinstalled, diags
+}
+
+// refresh sets the computed attributes of data from the API.
+func (r *dedicatedServerResource) refresh(ctx context.Context, data *dedicatedServerResourceModel) (diags diag.Diagnostics) {
+	info, err := r.svc.Server.GetServerByID(ctx, data.ID.ValueString())
+	if err != nil {
+		diags.AddError(
+			"Unable to refresh resource",
+			"An unexpected error occurred while attempting to refresh the resource."+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return diags
+	}
+	data.setServerInfo(info)
+	if data.Password.IsUnknown() {
+		data.Password = types.StringNull()
+	}
+	return diags
+}
+
+// waitForInstall waits for the dedicated server to be installed.
+func (r *dedicatedServerResource) waitForInstall(ctx context.Context, id string, timeout time.Duration) error {
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		info, infoErr := r.svc.Server.GetServerByID(ctx, id)
+		if infoErr != nil {
+			return retry.NonRetryableError(infoErr)
+		}
+		if info.Response.ServerInstall {
+			return retry.RetryableError(fmt.Errorf("dedicated server not installed yet"))
+		}
+		return nil
+	})
+}
+
+func (m *dedicatedServerResourceModel) setServerInfo(info *server.ServerReadResponse) {
+	m.Hostname = types.StringValue(info.Response.ServerInfo.Hostname)
+	m.OsId = types.StringValue(info.Response.ServerInfo.OsId)
+	m.Os = types.StringValue(info.Response.ServerInfo.Os)
+	m.IPAddress = types.StringValue(info.Response.ServerInfo.IpAddress)
+	m.Location = types.StringValue(info.Response.ServerInfo.Location)
+	m.Model = types.StringValue(info.Response.ServerInfo.Model)
+}
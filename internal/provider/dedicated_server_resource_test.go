@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+const testAccDedicatedServerResource = `
+resource "oneprovider_dedicated_server" "db" {
+	server_id = "%s"
+	hostname  = "dedicated-adopted"
+}
+`
+
+const testAccDedicatedServerResourceReinstall = `
+resource "oneprovider_dedicated_server" "db" {
+	server_id = "%s"
+	hostname  = "dedicated-reinstalled"
+	os_id     = "25"
+}
+`
+
+const testAccDedicatedServerResourceStalled = `
+resource "oneprovider_dedicated_server" "db" {
+	server_id = "%s"
+	os_id     = "%s"
+
+	timeouts {
+		create = "2s"
+		update = "2s"
+	}
+}
+`
+
+func TestAccDedicatedServerResource(t *testing.T) {
+	id := testAccDedicatedServerID(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testAccDedicatedServerResource, "unknown"),
+				ExpectError: regexp.MustCompile(`Dedicated server not found`),
+			},
+			// Adopting the server only renames it.
+			{
+				Config: fmt.Sprintf(testAccDedicatedServerResource, id),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"oneprovider_dedicated_server.db",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact(id),
+					),
+					statecheck.ExpectKnownValue(
+						"oneprovider_dedicated_server.db",
+						tfjsonpath.New("hostname"),
+						knownvalue.StringExact("dedicated-adopted"),
+					),
+					statecheck.ExpectKnownValue(
+						"oneprovider_dedicated_server.db",
+						tfjsonpath.New("os_id"),
+						knownvalue.NotNull(),
+					),
+					statecheck.ExpectKnownValue(
+						"oneprovider_dedicated_server.db",
+						tfjsonpath.New("password"),
+						knownvalue.Null(),
+					),
+				},
+			},
+			{
+				ResourceName:      "oneprovider_dedicated_server.db",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// The OS IDs of the real API are unknown, so reinstalling is only tested against the fake API.
+			{
+				SkipFunc: func() (bool, error) { return testAccFakeServer == nil, nil },
+				Config:   fmt.Sprintf(testAccDedicatedServerResourceReinstall, id),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("oneprovider_dedicated_server.db", plancheck.ResourceActionUpdate),
+						plancheck.ExpectUnknownValue("oneprovider_dedicated_server.db", tfjsonpath.New("password")),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"oneprovider_dedicated_server.db",
+						tfjsonpath.New("os"),
+						knownvalue.StringExact("Ubuntu 24.04 64bits"),
+					),
+					statecheck.ExpectKnownValue(
+						"oneprovider_dedicated_server.db",
+						tfjsonpath.New("hostname"),
+						knownvalue.StringExact("dedicated-reinstalled"),
+					),
+					statecheck.ExpectKnownValue(
+						"oneprovider_dedicated_server.db",
+						tfjsonpath.New("password"),
+						knownvalue.NotNull(),
+					),
+				},
+			},
+		},
+	})
+}
+
+// TestAccDedicatedServerResource_createInstallTimeout checks that an adopted server stays in the state
+// with its new OS and root password when the install following its reinstall doesn't complete in time.
+func TestAccDedicatedServerResource_createInstallTimeout(t *testing.T) {
+	if testAccFakeServer == nil {
+		t.Skip("stalling an install is only possible with the fake API")
+	}
+	id := testAccDedicatedServerID(t)
+	testAccFakeServer.StallDedicatedServerInstall(id)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testAccDedicatedServerResourceStalled, id, "25"),
+				ExpectError: regexp.MustCompile(`did not become ready`),
+			},
+			{
+				RefreshState: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("oneprovider_dedicated_server.db", "id", id),
+					resource.TestCheckResourceAttr("oneprovider_dedicated_server.db", "os_id", "25"),
+					resource.TestCheckResourceAttrWith("oneprovider_dedicated_server.db", "password", testAccDedicatedServerPassword(id)),
+				),
+			},
+		},
+	})
+}
+
+// TestAccDedicatedServerResource_updateInstallTimeout checks that a reinstalled server keeps its new OS
+// and root password in the state when the install doesn't complete in time.
+func TestAccDedicatedServerResource_updateInstallTimeout(t *testing.T) {
+	if testAccFakeServer == nil {
+		t.Skip("stalling an install is only possible with the fake API")
+	}
+	id := testAccDedicatedServerID(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The fake adds servers running OS 21, adopting them doesn't reinstall.
+			{
+				Config: fmt.Sprintf(testAccDedicatedServerResourceStalled, id, "21"),
+			},
+			{
+				PreConfig:   func() { testAccFakeServer.StallDedicatedServerInstall(id) },
+				Config:      fmt.Sprintf(testAccDedicatedServerResourceStalled, id, "25"),
+				ExpectError: regexp.MustCompile(`did not become ready`),
+			},
+			{
+				RefreshState: true,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("oneprovider_dedicated_server.db", "os_id", "25"),
+					resource.TestCheckResourceAttrWith("oneprovider_dedicated_server.db", "password", testAccDedicatedServerPassword(id)),
+				),
+			},
+		},
+	})
+}
+
+// testAccDedicatedServerPassword checks that the password is the current root password of the fake dedicated server.
+func testAccDedicatedServerPassword(id string) resource.CheckResourceAttrWithFunc {
+	return func(value string) error {
+		srv, _ := testAccFakeServer.DedicatedServer(id)
+		if value != srv.Password {
+			return fmt.Errorf("expected password %q, got %q", srv.Password, value)
+		}
+		return nil
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -53,3 +54,25 @@ func (rsi *resourceServiceInjector) Configure(ctx context.Context, req resource.
 	}
 	rsi.svc = svc
 }
+
+type ephemeralServiceInjector struct {
+	svc *oneprovider.Service
+}
+
+func (esi *ephemeralServiceInjector) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Always perform a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+	tflog.Info(ctx, "configuring ephemeral resource dependencies")
+	svc, ok := req.ProviderData.(*oneprovider.Service)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected service type",
+			fmt.Sprintf("Expected oneprovider.Service, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	esi.svc = svc
+}
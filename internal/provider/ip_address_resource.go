@@ -0,0 +1,233 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/client"
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/ip"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                = &ipAddressResource{}
+	_ resource.ResourceWithConfigure   = &ipAddressResource{}
+	_ resource.ResourceWithImportState = &ipAddressResource{}
+	_ resource.ResourceWithModifyPlan  = &ipAddressResource{}
+)
+
+type ipAddressResource struct {
+	resourceServiceInjector
+}
+
+type ipAddressResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	LocationId types.String `tfsdk:"location_id"`
+	Type       types.String `tfsdk:"type"`
+	IPAddress  types.String `tfsdk:"ip_address"`
+}
+
+func NewIPAddressResource() resource.Resource {
+	return &ipAddressResource{}
+}
+
+func (r *ipAddressResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ip_address"
+}
+
+func (r *ipAddressResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Allocate an additional IP address, e.g. a service IP address moved between VM instances with `oneprovider_ip_assignment`. " +
+			"The IP address is released on destroy.",
+		MarkdownDescription: "Allocate an additional IP address, e.g. a service IP address moved between VM instances with `oneprovider_ip_assignment`. " +
+			"The IP address is released on destroy.",
+		Attributes: map[string]schema.Attribute{
+			// Inputs
+			"location_id": schema.StringAttribute{
+				Description: "Location ID referencing where the IP address is allocated. It can only be assigned to VM instances of that location.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "Version of the IP address, either `ipv4` or `ipv6`. It must be available in the location. Defaults to `ipv4`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(ip.TypeIPv4),
+				Validators: []validator.String{
+					stringvalidator.OneOf(ip.TypeIPv4, ip.TypeIPv6),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			// Outputs
+			"id": schema.StringAttribute{
+				Description: "ID of the IP address",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ip_address": schema.StringAttribute{
+				Description: "The allocated IP address",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ipAddressResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ipAddressResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	allocated, err := r.svc.IP.AllocateAddress(ctx, &ip.AddressAllocateRequest{
+		LocationId: data.LocationId.ValueString(),
+		Type:       data.Type.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create resource",
+			"An unexpected error occurred while attempting to create the resource."+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(allocated.Response.Id)
+	data.IPAddress = types.StringValue(allocated.Response.IpAddress)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ipAddressResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ipAddressResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	address, err := r.svc.IP.GetAddressByID(ctx, data.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to refresh resource",
+			"An unexpected error occurred while attempting to refresh the resource."+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+
+	data.LocationId = types.StringValue(address.LocationId)
+	data.Type = types.StringValue(address.Type)
+	data.IPAddress = types.StringValue(address.IpAddress)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update is never called, every argument replaces the IP address.
+func (r *ipAddressResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ipAddressResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ipAddressResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ipAddressResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.svc.IP.ReleaseAddress(ctx, data.ID.ValueString())
+	// The IP address may have been assigned outside of Terraform, it must be unassigned first.
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		if address, getErr := r.svc.IP.GetAddressByID(ctx, data.ID.ValueString()); getErr == nil && address.VmId != "" {
+			if err = r.svc.IP.UnassignAddress(ctx, data.ID.ValueString()); err == nil {
+				err = r.svc.IP.ReleaseAddress(ctx, data.ID.ValueString())
+			}
+		}
+	}
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Unable to destroy resource",
+			"An unexpected error occurred while attempting to release the IP address."+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+}
+
+func (r *ipAddressResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// ModifyPlan checks that the IP version is available in the location.
+func (r *ipAddressResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// The location can only be checked once the provider is configured.
+	if req.Plan.Raw.IsNull() || r.svc == nil {
+		return
+	}
+
+	var plan ipAddressResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.LocationId.IsUnknown() || plan.Type.IsUnknown() {
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		var state ipAddressResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() || (plan.LocationId.Equal(state.LocationId) && plan.Type.Equal(state.Type)) {
+			return
+		}
+	}
+
+	location, err := r.svc.VM.GetLocationByID(ctx, plan.LocationId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("location_id"),
+			"Invalid location_id",
+			"The location could not be found.\n\n"+err.Error(),
+		)
+		return
+	}
+
+	if (plan.Type.ValueString() == ip.TypeIPv4 && !location.SupportsIPv4()) ||
+		(plan.Type.ValueString() == ip.TypeIPv6 && !location.SupportsIPv6()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("type"),
+			"Invalid type",
+			fmt.Sprintf("%s addresses are not available in location %s (%s).", plan.Type.ValueString(), location.Id, location.City),
+		)
+	}
+}
@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+const testAccIPAddressResource = `
+resource "oneprovider_ip_address" "service" {
+	location_id = "%s"
+	type        = "%s"
+}
+`
+
+func TestAccIPAddressResource(t *testing.T) {
+	testAccSkipUnlessFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Oslo only provides IPv6 addresses.
+			{
+				Config:      fmt.Sprintf(testAccIPAddressResource, "86", "ipv4"),
+				ExpectError: regexp.MustCompile(`Invalid type`),
+			},
+			{
+				Config: fmt.Sprintf(testAccIPAddressResource, "33", "ipv4"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"oneprovider_ip_address.service",
+						tfjsonpath.New("id"),
+						knownvalue.NotNull(),
+					),
+					statecheck.ExpectKnownValue(
+						"oneprovider_ip_address.service",
+						tfjsonpath.New("ip_address"),
+						knownvalue.StringRegexp(regexp.MustCompile(`^\d+\.\d+\.\d+\.\d+$`)),
+					),
+				},
+			},
+			{
+				ResourceName:      "oneprovider_ip_address.service",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
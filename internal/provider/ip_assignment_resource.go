@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/client"
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/ip"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+var (
+	_ resource.Resource                = &ipAssignmentResource{}
+	_ resource.ResourceWithConfigure   = &ipAssignmentResource{}
+	_ resource.ResourceWithImportState = &ipAssignmentResource{}
+)
+
+type ipAssignmentResource struct {
+	resourceServiceInjector
+}
+
+type ipAssignmentResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	IpId      types.String `tfsdk:"ip_id"`
+	VmId      types.String `tfsdk:"vm_id"`
+	IPAddress types.String `tfsdk:"ip_address"`
+}
+
+func NewIPAssignmentResource() resource.Resource {
+	return &ipAssignmentResource{}
+}
+
+func (r *ipAssignmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ip_assignment"
+}
+
+func (r *ipAssignmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Assign an `oneprovider_ip_address` to a VM instance. Changing the VM instance moves the IP address in place, " +
+			"e.g. during a blue/green cutover. The IP address is unassigned on destroy.",
+		MarkdownDescription: "Assign an `oneprovider_ip_address` to a VM instance. Changing the VM instance moves the IP address in place, " +
+			"e.g. during a blue/green cutover. The IP address is unassigned on destroy.",
+		Attributes: map[string]schema.Attribute{
+			// Inputs
+			"ip_id": schema.StringAttribute{
+				Description: "ID of the `oneprovider_ip_address` to assign",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"vm_id": schema.StringAttribute{
+				Description: "ID of the VM instance the IP address is routed to. It must be in the location of the IP address. " +
+					"The IP address still has to be configured in the operating system of the VM instance.",
+				Required: true,
+			},
+			// Outputs
+			"id": schema.StringAttribute{
+				Description: "ID of the assigned IP address",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ip_address": schema.StringAttribute{
+				Description: "The assigned IP address",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ipAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ipAssignmentResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	address, err := r.assign(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create resource",
+			"An unexpected error occurred while attempting to create the resource."+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+
+	data.ID = data.IpId
+	data.IPAddress = types.StringValue(address.IpAddress)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ipAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ipAssignmentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	address, err := r.svc.IP.GetAddressByID(ctx, data.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to refresh resource",
+			"An unexpected error occurred while attempting to refresh the resource."+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+	// The IP address was unassigned outside of Terraform, e.g. when its VM instance was destroyed.
+	if address.VmId == "" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.IpId = data.ID
+	data.VmId = types.StringValue(address.VmId)
+	data.IPAddress = types.StringValue(address.IpAddress)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ipAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ipAssignmentResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The API moves the IP address without unassigning it first.
+	if _, err := r.assign(ctx, &data); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update resource",
+			"An unexpected error occurred while attempting to update the resource."+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ipAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ipAssignmentResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The IP address may already be released.
+	err := r.svc.IP.UnassignAddress(ctx, data.ID.ValueString())
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Unable to destroy resource",
+			"An unexpected error occurred while attempting to unassign the IP address."+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+}
+
+func (r *ipAssignmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// assign routes the IP address to the VM instance, then waits for the API to report it.
+func (r *ipAssignmentResource) assign(ctx context.Context, data *ipAssignmentResourceModel) (*ip.AddressReadResponse, error) {
+	err := r.svc.IP.AssignAddress(ctx, &ip.AddressAssignRequest{
+		IpId: data.IpId.ValueString(),
+		VmId: data.VmId.ValueString(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var address *ip.AddressReadResponse
+	// OneProvider backend needs time to apply changes, so we retry with backoff.
+	err = retry.RetryContext(ctx, 30*time.Second, func() *retry.RetryError {
+		var getErr error
+		address, getErr = r.svc.IP.GetAddressByID(ctx, data.IpId.ValueString())
+		if getErr != nil {
+			return retry.NonRetryableError(getErr)
+		}
+		if address.VmId != data.VmId.ValueString() {
+			return retry.RetryableError(fmt.Errorf("ip address is not assigned yet"))
+		}
+		return nil
+	})
+	return address, err
+}
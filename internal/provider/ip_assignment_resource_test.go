@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+const testAccIPAssignmentResource = `
+data "oneprovider_vm_size" "small" {name = "02d30c1"}
+data "oneprovider_vm_location" "brussels" {city = "Brussels"}
+resource "oneprovider_vm_instance" "blue" {
+	location_id      = data.oneprovider_vm_location.brussels.id
+	instance_size_id = data.oneprovider_vm_size.small.id
+	template_id      = "1194"
+	hostname         = "blue"
+}
+resource "oneprovider_vm_instance" "green" {
+	location_id      = data.oneprovider_vm_location.brussels.id
+	instance_size_id = data.oneprovider_vm_size.small.id
+	template_id      = "1194"
+	hostname         = "green"
+}
+
+resource "oneprovider_ip_address" "service" {
+	location_id = data.oneprovider_vm_location.brussels.id
+}
+
+resource "oneprovider_ip_assignment" "service" {
+	ip_id = oneprovider_ip_address.service.id
+	vm_id = oneprovider_vm_instance.%s.id
+}
+`
+
+func TestAccIPAssignmentResource(t *testing.T) {
+	testAccSkipUnlessFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccIPAssignmentResource, "blue"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.CompareValuePairs(
+						"oneprovider_ip_assignment.service",
+						tfjsonpath.New("vm_id"),
+						"oneprovider_vm_instance.blue",
+						tfjsonpath.New("id"),
+						compare.ValuesSame(),
+					),
+					statecheck.CompareValuePairs(
+						"oneprovider_ip_assignment.service",
+						tfjsonpath.New("ip_address"),
+						"oneprovider_ip_address.service",
+						tfjsonpath.New("ip_address"),
+						compare.ValuesSame(),
+					),
+				},
+			},
+			// The cutover moves the IP address in place.
+			{
+				Config: fmt.Sprintf(testAccIPAssignmentResource, "green"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("oneprovider_ip_assignment.service", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.CompareValuePairs(
+						"oneprovider_ip_assignment.service",
+						tfjsonpath.New("vm_id"),
+						"oneprovider_vm_instance.green",
+						tfjsonpath.New("id"),
+						compare.ValuesSame(),
+					),
+				},
+			},
+			{
+				ResourceName:      "oneprovider_ip_assignment.service",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -3,26 +3,42 @@ package provider
 import (
 	"context"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider"
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/client"
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/vm"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 )
 
 const (
-	ApiKeyEnvVar    = "ONEPROVIDER_API_KEY"
-	ClientKeyEnvVar = "ONEPROVIDER_CLIENT_KEY"
+	ApiKeyEnvVar         = "ONEPROVIDER_API_KEY"
+	ClientKeyEnvVar      = "ONEPROVIDER_CLIENT_KEY"
+	EndpointEnvVar       = "ONEPROVIDER_ENDPOINT"
+	RateLimitEnvVar      = "ONEPROVIDER_RATE_LIMIT"
+	RateLimitBurstEnvVar = "ONEPROVIDER_RATE_LIMIT_BURST"
+
 	DefaultEndpoint = "https://api.oneprovider.com"
 )
 
 // Ensure OneProvider satisfies various provider interfaces.
-var _ provider.Provider = &OneProvider{}
+var (
+	_ provider.Provider                       = &OneProvider{}
+	_ provider.ProviderWithEphemeralResources = &OneProvider{}
+)
 
 // OneProvider defines the provider implementation.
 type OneProvider struct {
@@ -34,9 +50,17 @@ type OneProvider struct {
 
 // OneProviderModel describes the provider data model.
 type OneProviderModel struct {
-	ApiKey    types.String `tfsdk:"api_key"`
-	ClientKey types.String `tfsdk:"client_key"`
-	Endpoint  types.String `tfsdk:"endpoint"`
+	ApiKey                 types.String  `tfsdk:"api_key"`
+	ClientKey              types.String  `tfsdk:"client_key"`
+	Endpoint               types.String  `tfsdk:"endpoint"`
+	RetryMaxAttempts       types.Int64   `tfsdk:"retry_max_attempts"`
+	RetryMinBackoff        types.String  `tfsdk:"retry_min_backoff"`
+	RetryMaxBackoff        types.String  `tfsdk:"retry_max_backoff"`
+	RetryJitter            types.Bool    `tfsdk:"retry_jitter"`
+	RetryablePostEndpoints types.List    `tfsdk:"retryable_post_endpoints"`
+	RateLimit              types.Float64 `tfsdk:"rate_limit"`
+	RateLimitBurst         types.Int64   `tfsdk:"rate_limit_burst"`
+	CatalogCacheTTL        types.String  `tfsdk:"catalog_cache_ttl"`
 }
 
 func (p *OneProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -58,7 +82,49 @@ func (p *OneProvider) Schema(ctx context.Context, req provider.SchemaRequest, re
 				Sensitive:   true,
 			},
 			"endpoint": schema.StringAttribute{
-				Description: "URI for OneProvider API. Defaults to https://api.oneprovider.com",
+				Description: "URI for OneProvider API. May also be provided via ONEPROVIDER_ENDPOINT environment variable. Defaults to https://api.oneprovider.com",
+				Optional:    true,
+			},
+			"retry_max_attempts": schema.Int64Attribute{
+				Description: "Maximum number of attempts for a retryable API call, including the first one. Set to 1 to disable retries. Defaults to 4.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"retry_min_backoff": schema.StringAttribute{
+				Description: "Wait duration before the first retry, doubled on each subsequent attempt (e.g. \"500ms\", \"2s\"). Defaults to 1s.",
+				Optional:    true,
+			},
+			"retry_max_backoff": schema.StringAttribute{
+				Description: "Maximum wait duration between two attempts, also capping the Retry-After header sent by the API. Defaults to 30s.",
+				Optional:    true,
+			},
+			"retry_jitter": schema.BoolAttribute{
+				Description: "Randomize the wait duration between attempts. Defaults to true.",
+				Optional:    true,
+			},
+			"retryable_post_endpoints": schema.ListAttribute{
+				Description: "POST endpoints that are safe to retry (e.g. \"/vm/hostname\"). Only GET requests are retried by default.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"rate_limit": schema.Float64Attribute{
+				Description: "Maximum number of API requests per second, shared by all resources and data sources. Set to 0 to disable rate limiting. May also be provided via ONEPROVIDER_RATE_LIMIT environment variable. Defaults to 10.",
+				Optional:    true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"rate_limit_burst": schema.Int64Attribute{
+				Description: "Maximum number of API requests allowed to be sent at once above rate_limit. May also be provided via ONEPROVIDER_RATE_LIMIT_BURST environment variable. Defaults to 10.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"catalog_cache_ttl": schema.StringAttribute{
+				Description: "How long the templates, locations and sizes catalogs are cached by the provider (e.g. \"10m\"). Set to \"0s\" to always fetch them from the API. Defaults to 5m.",
 				Optional:    true,
 			},
 		},
@@ -75,6 +141,9 @@ func (p *OneProvider) Configure(ctx context.Context, req provider.ConfigureReque
 	}
 
 	endpoint := DefaultEndpoint
+	if v := os.Getenv(EndpointEnvVar); v != "" {
+		endpoint = v
+	}
 	if !providerConfiguration.Endpoint.IsNull() {
 		endpoint = providerConfiguration.Endpoint.ValueString()
 	}
@@ -105,11 +174,33 @@ func (p *OneProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		)
 	}
 
+	retryPolicy := retryPolicyFromModel(ctx, &providerConfiguration, &resp.Diagnostics)
+	rateLimit, rateLimitBurst := rateLimitFromModel(&providerConfiguration, &resp.Diagnostics)
+
+	catalogCacheTTL := vm.DefaultCatalogCacheTTL
+	if !providerConfiguration.CatalogCacheTTL.IsNull() {
+		d, err := time.ParseDuration(providerConfiguration.CatalogCacheTTL.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("catalog_cache_ttl"),
+				"Invalid catalog_cache_ttl",
+				"catalog_cache_ttl must be a valid duration: "+err.Error(),
+			)
+		}
+		catalogCacheTTL = d
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	svc, err := oneprovider.NewService(endpoint, apiKey, clientKey)
+	svc, err := oneprovider.NewService(endpoint, apiKey, clientKey,
+		oneprovider.WithClientOptions(
+			client.WithRetryPolicy(retryPolicy),
+			client.WithRateLimit(rateLimit, rateLimitBurst),
+		),
+		oneprovider.WithCatalogCacheTTL(catalogCacheTTL),
+	)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create OneProvider API client",
@@ -121,12 +212,102 @@ func (p *OneProvider) Configure(ctx context.Context, req provider.ConfigureReque
 	}
 	resp.DataSourceData = svc
 	resp.ResourceData = svc
+	resp.EphemeralResourceData = svc
+}
+
+// retryPolicyFromModel overlays the retry settings of the provider block on client.DefaultRetryPolicy.
+func retryPolicyFromModel(ctx context.Context, m *OneProviderModel, diags *diag.Diagnostics) client.RetryPolicy {
+	policy := client.DefaultRetryPolicy()
+
+	if !m.RetryMaxAttempts.IsNull() {
+		policy.MaxAttempts = int(m.RetryMaxAttempts.ValueInt64())
+	}
+	if !m.RetryMinBackoff.IsNull() {
+		d, err := time.ParseDuration(m.RetryMinBackoff.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("retry_min_backoff"),
+				"Invalid retry_min_backoff",
+				"retry_min_backoff must be a valid duration: "+err.Error(),
+			)
+		}
+		policy.MinBackoff = d
+	}
+	if !m.RetryMaxBackoff.IsNull() {
+		d, err := time.ParseDuration(m.RetryMaxBackoff.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("retry_max_backoff"),
+				"Invalid retry_max_backoff",
+				"retry_max_backoff must be a valid duration: "+err.Error(),
+			)
+		}
+		policy.MaxBackoff = d
+	}
+	if policy.MinBackoff > policy.MaxBackoff {
+		diags.AddAttributeError(
+			path.Root("retry_min_backoff"),
+			"Invalid retry backoff",
+			"retry_min_backoff cannot be greater than retry_max_backoff.",
+		)
+	}
+	if !m.RetryJitter.IsNull() {
+		policy.Jitter = m.RetryJitter.ValueBool()
+	}
+	if !m.RetryablePostEndpoints.IsNull() {
+		diags.Append(m.RetryablePostEndpoints.ElementsAs(ctx, &policy.RetryablePostEndpoints, false)...)
+	}
+
+	return policy
+}
+
+// rateLimitFromModel resolves the rate limit settings from the provider block,
+// then from the environment, then from the client defaults.
+func rateLimitFromModel(m *OneProviderModel, diags *diag.Diagnostics) (float64, int) {
+	rateLimit := float64(client.DefaultRateLimit)
+	if v := os.Getenv(RateLimitEnvVar); v != "" {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil || parsed < 0 {
+			diags.AddAttributeError(
+				path.Root("rate_limit"),
+				"Invalid "+RateLimitEnvVar+" environment variable",
+				RateLimitEnvVar+" must be a number and must not be negative, got: "+v,
+			)
+		}
+		rateLimit = parsed
+	}
+	if !m.RateLimit.IsNull() {
+		rateLimit = m.RateLimit.ValueFloat64()
+	}
+
+	burst := client.DefaultRateLimitBurst
+	if v := os.Getenv(RateLimitBurstEnvVar); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 {
+			diags.AddAttributeError(
+				path.Root("rate_limit_burst"),
+				"Invalid "+RateLimitBurstEnvVar+" environment variable",
+				RateLimitBurstEnvVar+" must be a strictly positive integer, got: "+v,
+			)
+		}
+		burst = parsed
+	}
+	if !m.RateLimitBurst.IsNull() {
+		burst = int(m.RateLimitBurst.ValueInt64())
+	}
+
+	return rateLimit, burst
 }
 
 func (p *OneProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewVmInstanceResource,
 		NewSSHKeyResource,
+		NewReverseDNSResource,
+		NewVmSnapshotResource,
+		NewDedicatedServerResource,
+		NewIPAddressResource,
+		NewIPAssignmentResource,
 	}
 }
 
@@ -136,6 +317,19 @@ func (p *OneProvider) DataSources(ctx context.Context) []func() datasource.DataS
 		NewVMLocationDataSource,
 		NewSSHKeyDataSource,
 		NewVmSizeDataSource,
+		NewVmInstancesDataSource,
+		NewVmLocationsDataSource,
+		NewVmSizesDataSource,
+		NewVmTemplatesDataSource,
+		NewSSHKeysDataSource,
+		NewVmSnapshotsDataSource,
+		NewDedicatedServerDataSource,
+	}
+}
+
+func (p *OneProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewVmPasswordEphemeralResource,
 	}
 }
 
@@ -1,9 +1,13 @@
 package provider
 
 import (
+	"log"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider"
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/oneprovidertest"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
@@ -15,6 +19,45 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"oneprovider": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// testAccFakeServer is set when acceptance tests run against the in-process fake API.
+var testAccFakeServer *oneprovidertest.Server
+
+// TestMain points acceptance tests to the fake API when no real credentials are
+// provided, so that the suite can run offline and without spending money.
+func TestMain(m *testing.M) {
+	_, hasApiKey := os.LookupEnv(ApiKeyEnvVar)
+	if os.Getenv("TF_ACC") != "" && !hasApiKey {
+		testAccFakeServer = oneprovidertest.NewServer()
+		log.Printf("[INFO] %s is not set, running acceptance tests against fake API at %s", ApiKeyEnvVar, testAccFakeServer.URL)
+
+		for k, v := range map[string]string{
+			ApiKeyEnvVar:    oneprovidertest.APIKey,
+			ClientKeyEnvVar: oneprovidertest.ClientKey,
+			EndpointEnvVar:  testAccFakeServer.URL,
+		} {
+			if err := os.Setenv(k, v); err != nil {
+				log.Fatalf("failed to set %s: %v", k, err)
+			}
+		}
+	}
+
+	code := m.Run()
+
+	if testAccFakeServer != nil {
+		testAccFakeServer.Close()
+	}
+	os.Exit(code)
+}
+
+// testAccSkipUnlessFakeAPI skips tests calling endpoints only known from the fake API. They must not run
+// against the real, billed API until those endpoints are checked against real API answers.
+func testAccSkipUnlessFakeAPI(t *testing.T) {
+	t.Helper()
+	if testAccFakeServer == nil {
+		t.Skip("endpoints not checked against the real API yet, only running against the fake API")
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	// You can add code here to run prior to any test case execution, for example assertions
 	// about the appropriate environment variables being set are common to see in a pre-check
@@ -26,3 +69,30 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatalf("missing %s environment variable", ClientKeyEnvVar)
 	}
 }
+
+// testAccService returns a service talking to the same API as the provider under test.
+func testAccService(t *testing.T) *oneprovider.Service {
+	endpoint := DefaultEndpoint
+	if v := os.Getenv(EndpointEnvVar); v != "" {
+		endpoint = v
+	}
+	svc, err := oneprovider.NewService(endpoint, os.Getenv(ApiKeyEnvVar), os.Getenv(ClientKeyEnvVar))
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+	return svc
+}
+
+// testAccDedicatedServerID returns the ID of a dedicated server to adopt. Dedicated servers can't be
+// ordered through the API, so one is added to the fake API, or read from
+// ONEPROVIDER_TEST_DEDICATED_SERVER_ID when running against the real one. The server is renamed by the tests.
+func testAccDedicatedServerID(t *testing.T) string {
+	if testAccFakeServer != nil {
+		return testAccFakeServer.AddDedicatedServer(strings.ToLower(t.Name()))
+	}
+	id := os.Getenv("ONEPROVIDER_TEST_DEDICATED_SERVER_ID")
+	if id == "" {
+		t.Skip("ONEPROVIDER_TEST_DEDICATED_SERVER_ID is not set, skipping dedicated server test")
+	}
+	return id
+}
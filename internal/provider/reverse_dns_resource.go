@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/client"
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/ip"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+var (
+	_ resource.Resource                = &reverseDNSResource{}
+	_ resource.ResourceWithConfigure   = &reverseDNSResource{}
+	_ resource.ResourceWithImportState = &reverseDNSResource{}
+)
+
+// hostnameRegexp matches fully qualified domain names, without trailing dot.
+var hostnameRegexp = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)+[a-zA-Z]{2,63}$`)
+
+type reverseDNSResource struct {
+	resourceServiceInjector
+}
+
+type reverseDNSResourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	IpAddress types.String `tfsdk:"ip_address"`
+	Hostname  types.String `tfsdk:"hostname"`
+}
+
+func NewReverseDNSResource() resource.Resource {
+	return &reverseDNSResource{}
+}
+
+func (r *reverseDNSResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_reverse_dns"
+}
+
+func (r *reverseDNSResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Manage the reverse DNS (PTR) record of an IP address. The record is reset to the OneProvider default on destroy.",
+		MarkdownDescription: "Manage the reverse DNS (PTR) record of an IP address. The record is reset to the OneProvider default on destroy.",
+		Attributes: map[string]schema.Attribute{
+			// Inputs
+			"ip_address": schema.StringAttribute{
+				Description: "IPv4 or IPv6 address of the account, e.g. `oneprovider_vm_instance.ip_address`.",
+				Required:    true,
+				Validators: []validator.String{
+					ipAddressValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"hostname": schema.StringAttribute{
+				Description: "Fully qualified domain name the IP address resolves to, without trailing dot.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(hostnameRegexp, "must be a fully qualified domain name without trailing dot"),
+				},
+			},
+			// Outputs
+			"id": schema.StringAttribute{
+				Description: "IP address of the record.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *reverseDNSResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data reverseDNSResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setReverseDNS(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create resource",
+			"An unexpected error occurred while attempting to create the resource."+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+
+	data.Id = data.IpAddress
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *reverseDNSResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *reverseDNSResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rdns, err := r.svc.IP.GetReverseDNS(ctx, data.Id.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to refresh resource",
+			"An unexpected error occurred while attempting to refresh the resource."+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+
+	data.IpAddress = data.Id
+	// The API may answer with a trailing dot.
+	if hostname := strings.TrimSuffix(rdns.Response.Hostname, "."); hostname != data.Hostname.ValueString() {
+		data.Hostname = types.StringValue(hostname)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *reverseDNSResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data reverseDNSResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.setReverseDNS(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update resource",
+			"An unexpected error occurred while attempting to update the resource."+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *reverseDNSResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data reverseDNSResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The IP address may already be gone with its VM instance.
+	err := r.svc.IP.ResetReverseDNS(ctx, data.Id.ValueString())
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Unable to destroy resource",
+			"An unexpected error occurred while attempting to reset the reverse DNS record."+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+}
+
+func (r *reverseDNSResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// setReverseDNS sets the record, then waits for the API to report it.
+func (r *reverseDNSResource) setReverseDNS(ctx context.Context, data *reverseDNSResourceModel) error {
+	err := r.svc.IP.SetReverseDNS(ctx, &ip.ReverseDNSUpdateRequest{
+		IpAddress: data.IpAddress.ValueString(),
+		Hostname:  data.Hostname.ValueString(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// OneProvider backend needs time to apply changes, so we retry with backoff.
+	return retry.RetryContext(ctx, 30*time.Second, func() *retry.RetryError {
+		rdns, getErr := r.svc.IP.GetReverseDNS(ctx, data.IpAddress.ValueString())
+		if getErr != nil {
+			return retry.NonRetryableError(getErr)
+		}
+		if strings.TrimSuffix(rdns.Response.Hostname, ".") != data.Hostname.ValueString() {
+			return retry.RetryableError(fmt.Errorf("reverse DNS record is not updated yet"))
+		}
+		return nil
+	})
+}
+
+var _ validator.String = ipAddressValidator{}
+
+// ipAddressValidator checks that a string is an IPv4 or IPv6 address.
+type ipAddressValidator struct{}
+
+func (v ipAddressValidator) Description(ctx context.Context) string {
+	return "value must be an IPv4 or IPv6 address"
+}
+
+func (v ipAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v ipAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if _, err := netip.ParseAddr(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid IP address",
+			"The value must be an IPv4 or IPv6 address: "+err.Error(),
+		)
+	}
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/ip"
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+const testAccReverseDNSResource = `
+data "oneprovider_vm_size" "small" {name = "02d30c1"}
+data "oneprovider_vm_location" "brussels" {city = "Brussels"}
+resource "oneprovider_vm_instance" "mail" {
+	location_id      = data.oneprovider_vm_location.brussels.id
+	instance_size_id = data.oneprovider_vm_size.small.id
+	template_id      = "1194"
+	hostname         = "mail"
+}
+
+resource "oneprovider_reverse_dns" "mail" {
+	ip_address = oneprovider_vm_instance.mail.ip_address
+	hostname   = "%s"
+}
+`
+
+func TestAccReverseDNSResource(t *testing.T) {
+	testAccSkipUnlessFakeAPI(t)
+
+	var address string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testAccReverseDNSResource, "not a hostname"),
+				ExpectError: regexp.MustCompile(`must be a fully qualified domain name`),
+			},
+			{
+				Config: fmt.Sprintf(testAccReverseDNSResource, "mail.example.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("oneprovider_reverse_dns.mail", "id", func(value string) error {
+						address = value
+						return nil
+					}),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.CompareValuePairs(
+						"oneprovider_reverse_dns.mail",
+						tfjsonpath.New("id"),
+						"oneprovider_vm_instance.mail",
+						tfjsonpath.New("ip_address"),
+						compare.ValuesSame(),
+					),
+					statecheck.ExpectKnownValue(
+						"oneprovider_reverse_dns.mail",
+						tfjsonpath.New("hostname"),
+						knownvalue.StringExact("mail.example.com"),
+					),
+				},
+			},
+			{
+				Config: fmt.Sprintf(testAccReverseDNSResource, "relay.example.com"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"oneprovider_reverse_dns.mail",
+						tfjsonpath.New("hostname"),
+						knownvalue.StringExact("relay.example.com"),
+					),
+				},
+			},
+			{
+				ResourceName:      "oneprovider_reverse_dns.mail",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// A record changed outside of Terraform is detected and set back.
+			{
+				PreConfig: func() {
+					err := testAccService(t).IP.SetReverseDNS(context.Background(), &ip.ReverseDNSUpdateRequest{
+						IpAddress: address,
+						Hostname:  "changed.example.com",
+					})
+					if err != nil {
+						t.Fatalf("failed to change the reverse DNS record out-of-band: %v", err)
+					}
+				},
+				Config: fmt.Sprintf(testAccReverseDNSResource, "relay.example.com"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("oneprovider_reverse_dns.mail", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"oneprovider_reverse_dns.mail",
+						tfjsonpath.New("hostname"),
+						knownvalue.StringExact("relay.example.com"),
+					),
+				},
+			},
+		},
+	})
+}
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -87,8 +88,21 @@ func (ds *sshKeyDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		}
 	}
 
+	if errors.Is(err, ssh.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"SSH key not found",
+			"No SSH key matches the given filter.\n\n"+
+				err.Error(),
+		)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("", "")
+		resp.Diagnostics.AddError(
+			"Unable to refresh datasource",
+			"An unexpected error occurred while creating the datasource read request."+
+				"Please report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
 		return
 	}
 
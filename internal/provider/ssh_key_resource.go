@@ -5,10 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/ssh"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -19,18 +21,54 @@ import (
 )
 
 var (
-	_ resource.Resource              = &sshKeyResource{}
-	_ resource.ResourceWithConfigure = &sshKeyResource{}
+	_ resource.Resource                = &sshKeyResource{}
+	_ resource.ResourceWithConfigure   = &sshKeyResource{}
+	_ resource.ResourceWithImportState = &sshKeyResource{}
+	_ resource.ResourceWithModifyPlan  = &sshKeyResource{}
 )
 
+// sshKeyImportNamePrefix marks an import ID as a key name rather than a UUID.
+const sshKeyImportNamePrefix = "name:"
+
 type sshKeyResource struct {
 	resourceServiceInjector
 }
 
 type sshKeyResourceModel struct {
-	Id        types.String `tfsdk:"id"`
-	Name      types.String `tfsdk:"name"`
-	PublicKey types.String `tfsdk:"public_key"`
+	Id                types.String      `tfsdk:"id"`
+	Name              types.String      `tfsdk:"name"`
+	PublicKey         sshPublicKeyValue `tfsdk:"public_key"`
+	KeyType           types.String      `tfsdk:"key_type"`
+	Comment           types.String      `tfsdk:"comment"`
+	FingerprintSHA256 types.String      `tfsdk:"fingerprint_sha256"`
+	FingerprintMD5    types.String      `tfsdk:"fingerprint_md5"`
+}
+
+// setKeyAttributes derives the computed key attributes from the public key.
+// They are null when the key can't be parsed, e.g. after importing a key the provider doesn't support.
+func (m *sshKeyResourceModel) setKeyAttributes() {
+	// Null and unknown keys read as an empty string, which doesn't parse either.
+	k, err := ssh.ParsePublicKey(m.PublicKey.ValueString())
+	if err != nil {
+		m.KeyType = types.StringNull()
+		m.Comment = types.StringNull()
+		m.FingerprintSHA256 = types.StringNull()
+		m.FingerprintMD5 = types.StringNull()
+		return
+	}
+	m.KeyType = types.StringValue(k.Type)
+	m.Comment = types.StringValue(k.Comment)
+	m.FingerprintSHA256 = types.StringValue(k.FingerprintSHA256())
+	m.FingerprintMD5 = types.StringValue(k.FingerprintMD5())
+}
+
+// normalizedPublicKey returns the public key sent to the API, with single spaces between fields.
+func (m *sshKeyResourceModel) normalizedPublicKey() string {
+	k, err := ssh.ParsePublicKey(m.PublicKey.ValueString())
+	if err != nil {
+		return m.PublicKey.ValueString()
+	}
+	return k.String()
 }
 
 func NewSSHKeyResource() resource.Resource {
@@ -58,11 +96,11 @@ func (r *sshKeyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 			"public_key": schema.StringAttribute{
-				Description: "Public key value.",
-				Required:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				Description: "Public key value in the authorized_keys format, e.g. `ssh-ed25519 AAAA... comment`. " +
+					"Supported key types are " + strings.Join(ssh.SupportedKeyTypes, ", ") + ". " +
+					"Changing it rotates the key in place, keeping the UUID of the SSH key.",
+				Required:   true,
+				CustomType: sshPublicKeyType{},
 			},
 			// Outputs
 			"id": schema.StringAttribute{
@@ -72,6 +110,22 @@ func (r *sshKeyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"key_type": schema.StringAttribute{
+				Description: "Type of the public key, e.g. `ssh-ed25519`.",
+				Computed:    true,
+			},
+			"comment": schema.StringAttribute{
+				Description: "Comment of the public key, empty when there is none.",
+				Computed:    true,
+			},
+			"fingerprint_sha256": schema.StringAttribute{
+				Description: "SHA256 fingerprint of the public key, as printed by `ssh-keygen -l`.",
+				Computed:    true,
+			},
+			"fingerprint_md5": schema.StringAttribute{
+				Description: "MD5 fingerprint of the public key, as printed by `ssh-keygen -l -E md5`.",
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -86,7 +140,7 @@ func (r *sshKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	createReq := &ssh.SshKeyCreateRequest{
 		Name:      data.Name.ValueString(),
-		PublicKey: data.PublicKey.ValueString(),
+		PublicKey: data.normalizedPublicKey(),
 	}
 
 	sshKey, err := r.svc.SSH.Create(ctx, createReq)
@@ -101,6 +155,7 @@ func (r *sshKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	data.Id = types.StringValue(sshKey.Response.Key.Uuid)
+	data.setKeyAttributes()
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -129,7 +184,11 @@ func (r *sshKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 	}
 
 	data.Name = types.StringValue(info.Name)
-	data.PublicKey = types.StringValue(info.Value)
+	// Keep the configured formatting unless the key itself changed.
+	if value := newSSHPublicKeyValue(info.Value); !data.PublicKey.sameKey(value) {
+		data.PublicKey = value
+	}
+	data.setKeyAttributes()
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -145,7 +204,7 @@ func (r *sshKeyResource) Update(ctx context.Context, req resource.UpdateRequest,
 	updateReq := &ssh.SshKeyUpdateRequest{
 		Uuid:      data.Id.ValueString(),
 		Name:      data.Name.ValueString(),
-		PublicKey: data.PublicKey.ValueString(),
+		PublicKey: data.normalizedPublicKey(),
 	}
 
 	_, err := r.svc.SSH.Update(ctx, updateReq)
@@ -168,6 +227,9 @@ func (r *sshKeyResource) Update(ctx context.Context, req resource.UpdateRequest,
 		if info.Name != data.Name.ValueString() {
 			return retry.RetryableError(fmt.Errorf("SSH key name is not updated yet"))
 		}
+		if info.Value != updateReq.PublicKey && !data.PublicKey.sameKey(newSSHPublicKeyValue(info.Value)) {
+			return retry.RetryableError(fmt.Errorf("SSH key value is not updated yet"))
+		}
 		return nil
 	})
 	if err != nil {
@@ -180,8 +242,8 @@ func (r *sshKeyResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	// PublicKey update is not supported at the moment.
 	data.Name = types.StringValue(updateReq.Name)
+	data.setKeyAttributes()
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -204,3 +266,49 @@ func (r *sshKeyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 }
+
+// ModifyPlan derives the computed key attributes from the planned public key,
+// so that they are known at plan time.
+func (r *sshKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data sshKeyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.PublicKey.IsUnknown() {
+		return
+	}
+
+	data.setKeyAttributes()
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
+}
+
+// ImportState accepts either the UUID of the key or its name prefixed by "name:".
+// Read fills the other attributes, so import blocks can generate the configuration.
+func (r *sshKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	name, byName := strings.CutPrefix(req.ID, sshKeyImportNamePrefix)
+	if !byName {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	info, err := r.svc.SSH.GetByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, ssh.ErrNotFound) {
+			resp.Diagnostics.AddError(
+				"Unable to import resource",
+				fmt.Sprintf("No SSH key named %q was found.", name),
+			)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to import resource",
+			"An unexpected error occurred while attempting to import the resource."+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), info.Uuid)...)
+}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"regexp"
 	"testing"
 )
 
@@ -61,6 +63,26 @@ func TestAccSshKeyResource(t *testing.T) {
 						tfjsonpath.New("public_key"),
 						knownvalue.StringExact("ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIMbAn3/YgZhhmsQIiGjOPOhODxpKXUo+LF3rFBvOOnYl"),
 					),
+					statecheck.ExpectKnownValue(
+						"oneprovider_ssh_key.key",
+						tfjsonpath.New("key_type"),
+						knownvalue.StringExact("ssh-ed25519"),
+					),
+					statecheck.ExpectKnownValue(
+						"oneprovider_ssh_key.key",
+						tfjsonpath.New("comment"),
+						knownvalue.StringExact(""),
+					),
+					statecheck.ExpectKnownValue(
+						"oneprovider_ssh_key.key",
+						tfjsonpath.New("fingerprint_sha256"),
+						knownvalue.StringExact("SHA256:q7ajZQUw6nWK4czH1JaoJH/MaXphrxLpmuOiZAojxX4"),
+					),
+					statecheck.ExpectKnownValue(
+						"oneprovider_ssh_key.key",
+						tfjsonpath.New("fingerprint_md5"),
+						knownvalue.StringExact("MD5:27:d2:e7:4d:97:ac:d2:ca:44:ae:8d:0d:35:bc:44:f0"),
+					),
 				},
 			},
 			{
@@ -75,10 +97,15 @@ func TestAccSshKeyResource(t *testing.T) {
 			},
 			{
 				Config: testAccSshKeyResourceKeyUpdate,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("oneprovider_ssh_key.key", plancheck.ResourceActionUpdate),
+					},
+				},
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttrWith("oneprovider_ssh_key.key", "id", func(value string) error {
-						if value == initialID {
-							return fmt.Errorf("expected resource to be recreated, but ID remained the same: %s", value)
+						if value != initialID {
+							return fmt.Errorf("expected key to be rotated in place, but ID changed from %s to %s", initialID, value)
 						}
 						return nil
 					}),
@@ -99,3 +126,111 @@ func TestAccSshKeyResource(t *testing.T) {
 		},
 	})
 }
+
+const testAccSshKeyResourceImport = `
+resource "oneprovider_ssh_key" "key" {
+	name       = "importme"
+	public_key = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIMbAn3/YgZhhmsQIiGjOPOhODxpKXUo+LF3rFBvOOnYi"
+}
+`
+
+func TestAccSshKeyResource_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSshKeyResourceImport,
+			},
+			// Import by UUID.
+			{
+				ResourceName:      "oneprovider_ssh_key.key",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Import by name.
+			{
+				ResourceName:      "oneprovider_ssh_key.key",
+				ImportState:       true,
+				ImportStateId:     "name:importme",
+				ImportStateVerify: true,
+			},
+			// Import by name through an import block.
+			{
+				Config:          testAccSshKeyResourceImport,
+				ResourceName:    "oneprovider_ssh_key.key",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+				ImportStateId:   "name:importme",
+			},
+			{
+				ResourceName:  "oneprovider_ssh_key.key",
+				ImportState:   true,
+				ImportStateId: "name:doesnotexist",
+				ExpectError:   regexp.MustCompile(`No SSH key named "doesnotexist" was found`),
+			},
+		},
+	})
+}
+
+func testAccSshKeyResourceFormatting(publicKey string) string {
+	return fmt.Sprintf(`
+resource "oneprovider_ssh_key" "key" {
+	name       = "samshouse"
+	public_key = %q
+}
+`, publicKey)
+}
+
+func TestAccSshKeyResource_formatting(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSshKeyResourceFormatting("ssh-ed25519 not-a-key"),
+				ExpectError: regexp.MustCompile(`Invalid SSH public key`),
+			},
+			{
+				Config:      testAccSshKeyResourceFormatting("ssh-dss AAAAB3NzaC1kc3M="),
+				ExpectError: regexp.MustCompile(`unsupported public key type "ssh-dss"`),
+			},
+			{
+				Config: testAccSshKeyResourceFormatting("ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIMbAn3/YgZhhmsQIiGjOPOhODxpKXUo+LF3rFBvOOnYz sam@shire\n"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"oneprovider_ssh_key.key",
+						tfjsonpath.New("public_key"),
+						knownvalue.StringExact("ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIMbAn3/YgZhhmsQIiGjOPOhODxpKXUo+LF3rFBvOOnYz sam@shire\n"),
+					),
+					statecheck.ExpectKnownValue(
+						"oneprovider_ssh_key.key",
+						tfjsonpath.New("comment"),
+						knownvalue.StringExact("sam@shire"),
+					),
+				},
+			},
+			// Changing the comment and whitespace updates the key in place.
+			{
+				Config: testAccSshKeyResourceFormatting("ssh-ed25519  AAAAC3NzaC1lZDI1NTE5AAAAIMbAn3/YgZhhmsQIiGjOPOhODxpKXUo+LF3rFBvOOnYz samwise@shire"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("oneprovider_ssh_key.key", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue(
+							"oneprovider_ssh_key.key",
+							tfjsonpath.New("comment"),
+							knownvalue.StringExact("samwise@shire"),
+						),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"oneprovider_ssh_key.key",
+						tfjsonpath.New("comment"),
+						knownvalue.StringExact("samwise@shire"),
+					),
+				},
+			},
+		},
+	})
+}
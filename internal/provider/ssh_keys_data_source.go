@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/ssh"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure = &sshKeysDataSource{}
+)
+
+type sshKeysDataSource struct {
+	datasourceServiceInjector
+}
+
+type sshKeysDataSourceModel struct {
+	// Input attributes (Optional/Required for filtering)
+	NamePrefix types.String `tfsdk:"name_prefix"`
+	NameRegex  types.String `tfsdk:"name_regex"`
+
+	// Output attributes (Computed)
+	Ids  types.List                  `tfsdk:"ids"`
+	Keys []sshKeysDataSourceKeyModel `tfsdk:"keys"`
+}
+
+type sshKeysDataSourceKeyModel struct {
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	PublicKey   types.String `tfsdk:"public_key"`
+	Fingerprint types.String `tfsdk:"fingerprint"`
+}
+
+func NewSSHKeysDataSource() datasource.DataSource {
+	return &sshKeysDataSource{}
+}
+
+func (ds *sshKeysDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ssh_keys"
+}
+
+func (ds *sshKeysDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Retrieve every SSH key of the account, optionally filtered by name.",
+		MarkdownDescription: "Retrieve every SSH key of the account, optionally filtered by name.",
+		Attributes: map[string]schema.Attribute{
+			"name_prefix": schema.StringAttribute{
+				MarkdownDescription: "Filter by name prefix.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("name_regex")),
+				},
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Filter by name matching this regular expression.",
+				Optional:            true,
+			},
+			"ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of the matching SSH keys, ready to be used as `ssh_keys` of a `oneprovider_vm_instance`.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"keys": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching SSH keys, sorted by name.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "ID of the SSH key.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the SSH key.",
+							Computed:            true,
+						},
+						"public_key": schema.StringAttribute{
+							MarkdownDescription: "SSH public key.",
+							Computed:            true,
+						},
+						"fingerprint": schema.StringAttribute{
+							MarkdownDescription: "SHA256 fingerprint of the public key, as printed by `ssh-keygen -l`. Null when the key can't be parsed.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (ds *sshKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *sshKeysDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid name_regex",
+				"name_regex must be a valid regular expression: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	keys, err := ds.svc.SSH.List(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to refresh datasource",
+			"An unexpected error occurred while creating the datasource read request."+
+				"Please report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+
+	slices.SortStableFunc(keys, func(a, b ssh.SshKeyReadResponse) int { return strings.Compare(a.Name, b.Name) })
+
+	ids := []string{}
+	data.Keys = []sshKeysDataSourceKeyModel{}
+	for _, k := range keys {
+		if !strings.HasPrefix(k.Name, data.NamePrefix.ValueString()) {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(k.Name) {
+			continue
+		}
+
+		fingerprint := types.StringNull()
+		if fp, fpErr := ssh.Fingerprint(k.Value); fpErr == nil {
+			fingerprint = types.StringValue(fp)
+		}
+		ids = append(ids, k.Uuid)
+		data.Keys = append(data.Keys, sshKeysDataSourceKeyModel{
+			Id:          types.StringValue(k.Uuid),
+			Name:        types.StringValue(k.Name),
+			PublicKey:   types.StringValue(k.Value),
+			Fingerprint: fingerprint,
+		})
+	}
+
+	idsValue, diags := types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Ids = idsValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+const testAccSshKeysDataSourceConfig = `
+resource "oneprovider_ssh_key" "alice" {
+	name       = "teamalice"
+	public_key = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKYsOrYIlOxNctNk/K/LHMVZRLs47n9JOU0UBpuiFCQR"
+}
+
+resource "oneprovider_ssh_key" "bob" {
+	name       = "teambob"
+	public_key = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAICFh/6fzb41tZe/HWInca3/NE2WmFKnV2sBZW5Z5HAyb"
+}
+
+resource "oneprovider_ssh_key" "ci" {
+	name       = "ci"
+	public_key = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIMbAn3/YgZhhmsQIiGjOPOhODxpKXUo+LF3rFBvOOnYk"
+}
+
+data "oneprovider_ssh_keys" "team" {
+	name_prefix = "team"
+	depends_on  = [oneprovider_ssh_key.alice, oneprovider_ssh_key.bob, oneprovider_ssh_key.ci]
+}
+
+data "oneprovider_ssh_keys" "bob" {
+	name_regex = "b+$"
+	depends_on = [oneprovider_ssh_key.alice, oneprovider_ssh_key.bob, oneprovider_ssh_key.ci]
+}
+`
+
+const testAccSshKeysDataSourceInvalidRegexConfig = `
+data "oneprovider_ssh_keys" "invalid" {name_regex = "("}
+`
+
+func TestAccSshKeysDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSshKeysDataSourceConfig,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.oneprovider_ssh_keys.team",
+						tfjsonpath.New("keys"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectExact(map[string]knownvalue.Check{
+								"id":          knownvalue.NotNull(),
+								"name":        knownvalue.StringExact("teamalice"),
+								"public_key":  knownvalue.StringExact("ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKYsOrYIlOxNctNk/K/LHMVZRLs47n9JOU0UBpuiFCQR"),
+								"fingerprint": knownvalue.StringExact("SHA256:5CgqPt7QqNpO53E700nK6U+TpzpeCKUb/AW83CUIoVg"),
+							}),
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"name": knownvalue.StringExact("teambob"),
+							}),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"data.oneprovider_ssh_keys.team",
+						tfjsonpath.New("ids"),
+						knownvalue.ListSizeExact(2),
+					),
+					statecheck.ExpectKnownValue(
+						"data.oneprovider_ssh_keys.bob",
+						tfjsonpath.New("keys"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"name": knownvalue.StringExact("teambob"),
+							}),
+						}),
+					),
+				},
+			},
+			{
+				Config:      testAccSshKeysDataSourceInvalidRegexConfig,
+				ExpectError: regexp.MustCompile("Invalid name_regex"),
+			},
+		},
+	})
+}
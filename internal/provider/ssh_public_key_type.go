@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/ssh"
+)
+
+var (
+	_ basetypes.StringTypable                    = sshPublicKeyType{}
+	_ basetypes.StringValuableWithSemanticEquals = sshPublicKeyValue{}
+	_ xattr.ValidateableAttribute                = sshPublicKeyValue{}
+)
+
+// sshPublicKeyType is a string holding an authorized_keys formatted public key.
+// Values differing only by whitespace or comment are semantically equal.
+type sshPublicKeyType struct {
+	basetypes.StringType
+}
+
+func (t sshPublicKeyType) Equal(o attr.Type) bool {
+	other, ok := o.(sshPublicKeyType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t sshPublicKeyType) String() string {
+	return "sshPublicKeyType"
+}
+
+func (t sshPublicKeyType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return sshPublicKeyValue{StringValue: in}, nil
+}
+
+func (t sshPublicKeyType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+	return sshPublicKeyValue{StringValue: stringValue}, nil
+}
+
+func (t sshPublicKeyType) ValueType(ctx context.Context) attr.Value {
+	return sshPublicKeyValue{}
+}
+
+type sshPublicKeyValue struct {
+	basetypes.StringValue
+}
+
+func newSSHPublicKeyValue(value string) sshPublicKeyValue {
+	return sshPublicKeyValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v sshPublicKeyValue) Equal(o attr.Value) bool {
+	other, ok := o.(sshPublicKeyValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v sshPublicKeyValue) Type(ctx context.Context) attr.Type {
+	return sshPublicKeyType{}
+}
+
+// StringSemanticEquals compares the key type and blob, ignoring whitespace and comment.
+func (v sshPublicKeyValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(sshPublicKeyValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			"An unexpected value type was received while performing semantic equality checks. "+
+				"Please report this to the provider developers.\n\n"+
+				fmt.Sprintf("Expected Value Type: %T\n", v)+
+				fmt.Sprintf("Got Value Type: %T", newValuable),
+		)
+		return false, diags
+	}
+
+	return v.sameKey(newValue), diags
+}
+
+// sameKey reports whether both values hold the same parsable key.
+func (v sshPublicKeyValue) sameKey(other sshPublicKeyValue) bool {
+	if v.IsNull() || v.IsUnknown() || other.IsNull() || other.IsUnknown() {
+		return false
+	}
+	a, err := ssh.ParsePublicKey(v.ValueString())
+	if err != nil {
+		return false
+	}
+	b, err := ssh.ParsePublicKey(other.ValueString())
+	if err != nil {
+		return false
+	}
+	return a.Equal(b)
+}
+
+func (v sshPublicKeyValue) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+	if _, err := ssh.ParsePublicKey(v.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid SSH public key",
+			"A string value was provided that is not a valid SSH public key in the authorized_keys format.\n\n"+
+				"Given Value: "+v.ValueString()+"\n"+
+				"Error: "+err.Error(),
+		)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/netip"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -11,13 +13,17 @@ import (
 	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/client"
 	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/vm"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 )
@@ -26,6 +32,7 @@ var (
 	_ resource.Resource                = &vmInstanceResource{}
 	_ resource.ResourceWithConfigure   = &vmInstanceResource{}
 	_ resource.ResourceWithImportState = &vmInstanceResource{}
+	_ resource.ResourceWithModifyPlan  = &vmInstanceResource{}
 )
 
 type vmInstanceResource struct {
@@ -33,17 +40,33 @@ type vmInstanceResource struct {
 }
 
 type vmInstanceResourceModel struct {
-	ID             types.String   `tfsdk:"id"`
-	LocationId     types.String   `tfsdk:"location_id"`
-	InstanceSizeId types.String   `tfsdk:"instance_size_id"`
-	TemplateId     types.String   `tfsdk:"template_id"`
-	Hostname       types.String   `tfsdk:"hostname"`
-	IPAddress      types.String   `tfsdk:"ip_address"`
-	Password       types.String   `tfsdk:"password"`
-	SshKeys        types.List     `tfsdk:"ssh_keys"`
-	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+	ID                        types.String   `tfsdk:"id"`
+	LocationId                types.String   `tfsdk:"location_id"`
+	InstanceSizeId            types.String   `tfsdk:"instance_size_id"`
+	TemplateId                types.String   `tfsdk:"template_id"`
+	SnapshotId                types.String   `tfsdk:"snapshot_id"`
+	Hostname                  types.String   `tfsdk:"hostname"`
+	IPAddress                 types.String   `tfsdk:"ip_address"`
+	EnableIPv6                types.Bool     `tfsdk:"enable_ipv6"`
+	IPv4Address               types.String   `tfsdk:"ipv4_address"`
+	IPv6Address               types.String   `tfsdk:"ipv6_address"`
+	IPv6Prefix                types.String   `tfsdk:"ipv6_prefix"`
+	Password                  types.String   `tfsdk:"password"`
+	SshKeys                   types.List     `tfsdk:"ssh_keys"`
+	PowerState                types.String   `tfsdk:"power_state"`
+	ReinstallOnTemplateChange types.Bool     `tfsdk:"reinstall_on_template_change"`
+	StorePassword             types.Bool     `tfsdk:"store_password"`
+	UserData                  types.String   `tfsdk:"user_data"`
+	UserDataBase64            types.String   `tfsdk:"user_data_base64"`
+	UserDataHash              types.String   `tfsdk:"user_data_hash"`
+	Timeouts                  timeouts.Value `tfsdk:"timeouts"`
 }
 
+const (
+	powerStateRunning = "running"
+	powerStateStopped = "stopped"
+)
+
 func NewVmInstanceResource() resource.Resource {
 	return &vmInstanceResource{}
 }
@@ -66,25 +89,67 @@ func (r *vmInstanceResource) Schema(ctx context.Context, req resource.SchemaRequ
 				},
 			},
 			"instance_size_id": schema.StringAttribute{
-				Description: "Instance size ID referencing the hardware specs of the VM instance",
-				Required:    true,
+				Description: "Instance size ID referencing the hardware specs of the VM instance. Changing it resizes the VM instance in place, stopping it during the operation if needed. " +
+					"A new size with a smaller disk is the only change that replaces the VM instance: whether the API refuses other resizes is only known during the apply, " +
+					"which then fails instead of replacing the VM instance.",
+				Required: true,
+			},
+			"template_id": schema.StringAttribute{
+				Description: "Template ID referencing the OS to use for that VM instance. Changing it replaces the VM instance unless `reinstall_on_template_change` is set. " +
+					"Exactly one of `template_id` and `snapshot_id` must be set, the template of the snapshot is used when the VM instance is created from a snapshot.",
+				Optional: true,
+				Computed: true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIf(
+						requiresReplaceUnlessReinstall,
+						"Replace the VM instance unless reinstall_on_template_change is true.",
+						"Replace the VM instance unless `reinstall_on_template_change` is true.",
+					),
 				},
 			},
-			"template_id": schema.StringAttribute{
-				Description: "Template ID referencing the OS to use for that VM instance",
-				Required:    true,
+			"snapshot_id": schema.StringAttribute{
+				Description: "ID of the `oneprovider_vm_snapshot` to create the VM instance from, to clone a golden image. " +
+					"The disk of `instance_size_id` must be at least as large as the snapshot. Changing it replaces the VM instance.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("template_id")),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"reinstall_on_template_change": schema.BoolAttribute{
+				Description: "Reinstall the VM instance in place when `template_id` changes instead of replacing it. The ID and IP address are kept, all data on the disk is lost and a new root password is generated. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"store_password": schema.BoolAttribute{
+				Description: "Store the root password returned on creation and reinstall in `password`. Set it to false to keep the password out of the state, " +
+					"and use the `oneprovider_vm_password` ephemeral resource to get a new one when needed. Defaults to true. " +
+					"That ephemeral resource resets the root password of the running VM instance whenever it is opened with `rotate` set to true, plans included.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
 			"hostname": schema.StringAttribute{
 				Description: "Hostname of the VM instance",
 				Required:    true,
 			},
+			"enable_ipv6": schema.BoolAttribute{
+				Description: "Route an IPv6 prefix to the VM instance, see `ipv6_address` and `ipv6_prefix`. The location must support IPv6, " +
+					"i.e. `ipv6` is \"1\" on the `oneprovider_vm_location` data source or true on the `oneprovider_vm_locations` one. Changing it replaces the VM instance. Defaults to false.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
 			"ssh_keys": schema.ListAttribute{
-				Description: "List of SSH keys UUID to add to the VM instance. Note: The OneProvider API does not return SSH key information, so the state reflects the configured values rather than the actual server state.",
+				Description: "List of SSH keys UUID authorized on the VM instance. Changing it attaches and detaches keys in place, without reinstalling the VM instance. " +
+					"Keys changed outside of Terraform are detected when the API reports the keys of the VM instance, otherwise the state reflects the configured values.",
 				ElementType: types.StringType,
 				// Schema Using Attribute Default must be computed when using default.
 				Computed: true,
@@ -96,6 +161,40 @@ func (r *vmInstanceResource) Schema(ctx context.Context, req resource.SchemaRequ
 					),
 				),
 			},
+			"user_data": schema.StringAttribute{
+				Description: "User data run by cloud-init on first boot, e.g. a `#cloud-config` document or a shell script. " +
+					"User data larger than 64 KiB is gzip compressed before being sent. The value is write-only and never stored in the state, " +
+					"see `user_data_hash`. Requires Terraform 1.11 or later.",
+				Optional:  true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("user_data_base64")),
+					stringvalidator.LengthAtLeast(1),
+					userDataValidator{},
+				},
+			},
+			"user_data_base64": schema.StringAttribute{
+				Description: "Base64 encoded user data, for binary content such as gzip compressed cloud-init configurations. " +
+					"Gzip compressed content is sent as is. The value is write-only and never stored in the state, " +
+					"see `user_data_hash`. Requires Terraform 1.11 or later.",
+				Optional:  true,
+				WriteOnly: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					userDataValidator{base64: true},
+				},
+			},
+			"power_state": schema.StringAttribute{
+				Description: "Desired power state of the VM instance, either `running` or `stopped`. Defaults to the current state of the VM instance, which is `running` after creation.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(powerStateRunning, powerStateStopped),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			// Outputs
 			"id": schema.StringAttribute{
 				Description: "ID of the VM instance. Generated by the provider.",
@@ -111,8 +210,34 @@ func (r *vmInstanceResource) Schema(ctx context.Context, req resource.SchemaRequ
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"ipv4_address": schema.StringAttribute{
+				Description: "IPv4 address of the VM instance. Null when the VM instance only has an IPv6 address.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ipv6_address": schema.StringAttribute{
+				Description: "IPv6 address of the VM instance. Null unless `enable_ipv6` is true.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"ipv6_prefix": schema.StringAttribute{
+				Description: "IPv6 prefix routed to the VM instance in CIDR notation, e.g. `2001:db8:1::/64`. Null unless `enable_ipv6` is true.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"user_data_hash": schema.StringAttribute{
+				Description: "SHA256 hash of the decoded user data, stored in the state instead of the user data itself. " +
+					"Changing the user data replaces the VM instance, unless there was none before, e.g. after an import, in which case only the hash is recorded.",
+				Computed: true,
+			},
 			"password": schema.StringAttribute{
-				Description: "Password of the root user",
+				Description: "Password of the root user. Null when `store_password` is false. It is not updated when the password is reset outside of this resource.",
 				Computed:    true,
 				Sensitive:   true,
 				PlanModifiers: []planmodifier.String{
@@ -164,15 +289,60 @@ func (r *vmInstanceResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	// User data is write-only, so it is only found in the configuration.
+	var config *vmInstanceResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	userData, err := config.userData()
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("user_data_base64"),
+			"Invalid user data",
+			"The value must be base64 encoded: "+err.Error(),
+		)
+		return
+	}
+	var encodedUserData string
+	if userData != nil {
+		encodedUserData, err = vm.EncodeUserData(userData)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid user data", err.Error())
+			return
+		}
+	}
+
 	createRequest := &vm.InstanceCreateRequest{
 		LocationId:     locationId,
 		InstanceSizeId: instanceSizeId,
 		TemplateId:     data.TemplateId.ValueString(),
+		SnapshotId:     data.SnapshotId.ValueString(),
 		Hostname:       data.Hostname.ValueString(),
 		SshKeys:        sshKeys,
+		UserData:       encodedUserData,
+		EnableIPv6:     data.EnableIPv6.ValueBool(),
 	}
 	vmInstance, err := r.svc.VM.CreateInstance(ctx, createRequest)
-	if err != nil {
+	switch {
+	case errors.Is(err, client.ErrLocationOutOfStock):
+		resp.Diagnostics.AddAttributeError(
+			path.Root("location_id"),
+			"Location out of stock",
+			"The requested location has no capacity left for this instance size. "+
+				"Please choose another location or instance size.\n\n"+
+				err.Error(),
+		)
+		return
+	case errors.Is(err, client.ErrInsufficientFunds):
+		resp.Diagnostics.AddError(
+			"Insufficient funds",
+			"The OneProvider account does not have enough credit to create the resource. "+
+				"Please top up the account and retry the operation.\n\n"+
+				err.Error(),
+		)
+		return
+	case err != nil:
 		resp.Diagnostics.AddError(
 			"Unable to create resource",
 			"An unexpected error occurred while attempting to create the resource."+
@@ -188,22 +358,7 @@ func (r *vmInstanceResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	err = retry.RetryContext(ctx, createTimeout, func() *retry.RetryError {
-		info, infoErr := r.svc.VM.GetInstanceByID(ctx, vmInstance.Response.Id)
-		if infoErr != nil {
-			return retry.NonRetryableError(infoErr)
-		}
-		if info.Response.ServerInstall || strings.ToLower(info.Response.ServerState.State) == "offline" {
-			return retry.RetryableError(fmt.Errorf("vm instance not ready yet"))
-		}
-		if info.Response.ServerInfo.IpAddress == "" {
-			// This should never been happening because when I do the create - I get an IP back.
-			// The fact that from the GET endpoint, there is some cases where ServerInfo.* is filled with empty
-			// values means that something is wrong in their backend.
-			return retry.RetryableError(fmt.Errorf("getInstance returned empty informations"))
-		}
-		return nil
-	})
+	info, err := r.waitForInstall(ctx, vmInstance.Response.Id, createTimeout)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to refresh resource",
@@ -217,7 +372,52 @@ func (r *vmInstanceResource) Create(ctx context.Context, req resource.CreateRequ
 	// Set the value for computed attributes.
 	data.ID = types.StringValue(vmInstance.Response.Id)
 	data.IPAddress = types.StringValue(vmInstance.Response.IpAddress)
-	data.Password = types.StringValue(vmInstance.Response.Password)
+	data.setAddresses(info)
+	data.UserDataHash = userDataHash(userData)
+	data.Password = types.StringNull()
+	if data.StorePassword.ValueBool() {
+		data.Password = types.StringValue(vmInstance.Response.Password)
+	}
+
+	// A VM instance created from a snapshot uses the template of the snapshot.
+	if data.TemplateId.IsUnknown() {
+		templateId, tErr := r.templateIdOf(ctx, info)
+		if tErr != nil {
+			// The VM instance exists at this point, keep it in the state so it's not leaked.
+			// Read looks the template up again while it is null.
+			data.TemplateId = types.StringNull()
+			data.PowerState = types.StringValue(powerStateRunning)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			resp.Diagnostics.AddError(
+				"Unable to refresh resource",
+				"An unexpected error occurred while attempting to read the template of the resource."+
+					"Please retry the operation or report this issue to the provider developers.\n\n"+
+					tErr.Error(),
+			)
+			return
+		}
+		data.TemplateId = templateId
+	}
+
+	// A new VM instance is always running, stop it if asked to.
+	if data.PowerState.ValueString() == powerStateStopped {
+		err = r.setPowerState(ctx, data.ID.ValueString(), powerStateStopped, createTimeout)
+		if err != nil {
+			// The VM instance exists at this point, keep it in the state so it's not leaked.
+			data.PowerState = types.StringValue(powerStateRunning)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			resp.Diagnostics.AddError(
+				"Unable to stop resource",
+				"The resource was created but could not be stopped."+
+					"Please retry the operation or report this issue to the provider developers.\n\n"+
+					err.Error(),
+			)
+			return
+		}
+	}
+	if data.PowerState.ValueString() != powerStateStopped {
+		data.PowerState = types.StringValue(powerStateRunning)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -231,8 +431,7 @@ func (r *vmInstanceResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	info, err := r.svc.VM.GetInstanceByID(ctx, data.ID.ValueString())
 	if err != nil {
-		var apiErr *client.APIError
-		if errors.As(err, &apiErr) && apiErr.Code == 810 {
+		if errors.Is(err, client.ErrNotFound) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
@@ -287,8 +486,41 @@ func (r *vmInstanceResource) Read(ctx context.Context, req resource.ReadRequest,
 		data.TemplateId = types.StringValue(strconv.Itoa(ti.Id))
 	}
 
+	// The state keeps the configured SSH keys when the API doesn't report them.
+	if keys := info.Response.ServerInfo.SshKeys; keys != nil {
+		var current []string
+		resp.Diagnostics.Append(data.SshKeys.ElementsAs(ctx, &current, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if data.SshKeys.IsNull() || !sameSSHKeys(current, keys) {
+			sshKeys, diags := types.ListValueFrom(ctx, types.StringType, keys)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			data.SshKeys = sshKeys
+		}
+	}
+
 	data.Hostname = types.StringValue(info.Response.ServerInfo.Hostname)
 	data.IPAddress = types.StringValue(info.Response.ServerInfo.IpAddress)
+	data.setAddresses(info)
+	if data.EnableIPv6.IsNull() {
+		data.EnableIPv6 = types.BoolValue(info.Response.ServerInfo.IPv6Address != "")
+	}
+	// A VM instance in transition keeps its last known power state, or is assumed to come up running.
+	if powerState, ok := powerStateFromInstance(info); ok {
+		data.PowerState = types.StringValue(powerState)
+	} else if data.PowerState.IsNull() || data.PowerState.IsUnknown() {
+		data.PowerState = types.StringValue(powerStateRunning)
+	}
+	if data.ReinstallOnTemplateChange.IsNull() {
+		data.ReinstallOnTemplateChange = types.BoolValue(false)
+	}
+	if data.StorePassword.IsNull() {
+		data.StorePassword = types.BoolValue(true)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -300,6 +532,119 @@ func (r *vmInstanceResource) Update(ctx context.Context, req resource.UpdateRequ
 	var plan *vmInstanceResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 
+	// We are reinstalling the VM instance with another template...
+	reinstalled := state.TemplateId != plan.TemplateId
+	if reinstalled {
+		var sshKeys []string
+		resp.Diagnostics.Append(plan.SshKeys.ElementsAs(ctx, &sshKeys, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		updateTimeout, diags := plan.Timeouts.Update(ctx, 5*time.Minute)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		reinstallRequest := &vm.InstanceReinstallRequest{
+			VmId:       plan.ID.ValueString(),
+			TemplateId: plan.TemplateId.ValueString(),
+			SshKeys:    sshKeys,
+		}
+		reinstall, err := r.svc.VM.ReinstallInstance(ctx, reinstallRequest)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to reinstall resource",
+				"An unexpected error occurred while attempting to reinstall the resource."+
+					"Please retry the operation or report this issue to the provider developers.\n\n"+
+					err.Error(),
+			)
+			return
+		}
+
+		// The disk is wiped at this point, record the new template and root password whatever happens next.
+		if plan.StorePassword.ValueBool() {
+			plan.Password = types.StringValue(reinstall.Response.Password)
+		}
+		state.TemplateId = plan.TemplateId
+		state.SshKeys = plan.SshKeys
+		state.Password = plan.Password
+		// A reinstalled VM instance is running, let the power state be reconciled below.
+		state.PowerState = types.StringValue(powerStateRunning)
+		// Later errors keep the state as set here instead of the prior one.
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+
+		_, err = r.waitForInstall(ctx, plan.ID.ValueString(), updateTimeout)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to refresh resource after reinstall",
+				"The reinstall was requested but the resource did not become ready."+
+					"Please retry the operation or report this issue to the provider developers.\n\n"+
+					err.Error(),
+			)
+			return
+		}
+	}
+
+	// We are attaching or detaching SSH keys, a reinstall already installed the planned ones...
+	if !reinstalled && !plan.SshKeys.Equal(state.SshKeys) {
+		var current, want []string
+		resp.Diagnostics.Append(state.SshKeys.ElementsAs(ctx, &current, false)...)
+		resp.Diagnostics.Append(plan.SshKeys.ElementsAs(ctx, &want, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		applied, err := r.updateSSHKeys(ctx, plan.ID.ValueString(), current, want)
+		if err != nil {
+			// Keep the keys attached or detached so far in the state so that only the others are retried.
+			if !sameSSHKeys(applied, current) {
+				sshKeys, diags := types.ListValueFrom(ctx, types.StringType, applied)
+				resp.Diagnostics.Append(diags...)
+				state.SshKeys = sshKeys
+				resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			}
+			resp.Diagnostics.AddError(
+				"Unable to update resource SSH keys",
+				"An unexpected error occurred while attempting to attach or detach SSH keys of the resource."+
+					"Please retry the operation or report this issue to the provider developers.\n\n"+
+					err.Error(),
+			)
+			return
+		}
+		state.SshKeys = plan.SshKeys
+	}
+
+	// We are resizing the VM instance...
+	if state.InstanceSizeId != plan.InstanceSizeId {
+		updateTimeout, diags := plan.Timeouts.Update(ctx, 5*time.Minute)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		stopped, err := r.resize(ctx, plan.ID.ValueString(), plan.InstanceSizeId.ValueString(), updateTimeout)
+		if err != nil {
+			// The VM instance could not be started again, record it so that the next apply starts it.
+			if stopped {
+				state.PowerState = types.StringValue(powerStateStopped)
+				resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			}
+			resp.Diagnostics.AddError(
+				"Unable to resize resource",
+				"An unexpected error occurred while attempting to resize the resource."+
+					"Please retry the operation or report this issue to the provider developers.\n\n"+
+					err.Error(),
+			)
+			return
+		}
+		// The VM instance had to be stopped, let the power state be reconciled below.
+		if stopped {
+			state.PowerState = types.StringValue(powerStateStopped)
+		}
+	}
+
 	// We are updating the hostname...
 	if state.Hostname != plan.Hostname {
 		updateRequest := &vm.InstanceHostnameUpdateRequest{
@@ -346,6 +691,29 @@ func (r *vmInstanceResource) Update(ctx context.Context, req resource.UpdateRequ
 		plan.Hostname = types.StringValue(updateRequest.Hostname)
 	}
 
+	// We are starting or stopping the VM instance...
+	if !plan.PowerState.IsUnknown() && plan.PowerState.ValueString() != state.PowerState.ValueString() {
+		updateTimeout, diags := plan.Timeouts.Update(ctx, 5*time.Minute)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		err := r.setPowerState(ctx, plan.ID.ValueString(), plan.PowerState.ValueString(), updateTimeout)
+		if err != nil {
+			// The other changes were applied, keep them in the state so that only the power state is retried.
+			plan.PowerState = state.PowerState
+			resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+			resp.Diagnostics.AddError(
+				"Unable to update resource power state",
+				"An unexpected error occurred while attempting to change the power state of the resource."+
+					"Please retry the operation or report this issue to the provider developers.\n\n"+
+					err.Error(),
+			)
+			return
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -363,7 +731,7 @@ func (r *vmInstanceResource) Delete(ctx context.Context, req resource.DeleteRequ
 	}
 
 	err := r.svc.VM.DestroyInstance(ctx, destroyRequest)
-	if err != nil {
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
 		resp.Diagnostics.AddError(
 			"Unable to destroy resource",
 			"An unexpected error occurred while attempting to destroy the resource."+
@@ -374,6 +742,409 @@ func (r *vmInstanceResource) Delete(ctx context.Context, req resource.DeleteRequ
 	}
 }
 
+// waitForInstall waits for the VM instance to be installed and online, then returns its details.
+func (r *vmInstanceResource) waitForInstall(ctx context.Context, id string, timeout time.Duration) (*vm.InstanceReadResponse, error) {
+	var info *vm.InstanceReadResponse
+	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		var infoErr error
+		info, infoErr = r.svc.VM.GetInstanceByID(ctx, id)
+		if infoErr != nil {
+			return retry.NonRetryableError(infoErr)
+		}
+		if info.Response.ServerInstall || strings.ToLower(info.Response.ServerState.State) == vm.InstanceStateOffline {
+			return retry.RetryableError(fmt.Errorf("vm instance not ready yet"))
+		}
+		if info.Response.ServerInfo.IpAddress == "" {
+			// This should never been happening because when I do the create - I get an IP back.
+			// The fact that from the GET endpoint, there is some cases where ServerInfo.* is filled with empty
+			// values means that something is wrong in their backend.
+			return retry.RetryableError(fmt.Errorf("getInstance returned empty informations"))
+		}
+		return nil
+	})
+	return info, err
+}
+
+// setAddresses sets the IPv4 and IPv6 attributes from the details of the VM instance.
+func (m *vmInstanceResourceModel) setAddresses(info *vm.InstanceReadResponse) {
+	m.IPv4Address = types.StringNull()
+	if addr, err := netip.ParseAddr(info.Response.ServerInfo.IpAddress); err == nil && addr.Is4() {
+		m.IPv4Address = types.StringValue(addr.String())
+	}
+	m.IPv6Address = types.StringNull()
+	if info.Response.ServerInfo.IPv6Address != "" {
+		m.IPv6Address = types.StringValue(info.Response.ServerInfo.IPv6Address)
+	}
+	m.IPv6Prefix = types.StringNull()
+	if info.Response.ServerInfo.IPv6Prefix != "" {
+		m.IPv6Prefix = types.StringValue(info.Response.ServerInfo.IPv6Prefix)
+	}
+}
+
+// templateIdOf looks up the template ID of the VM instance from its template name.
+func (r *vmInstanceResource) templateIdOf(ctx context.Context, info *vm.InstanceReadResponse) (types.String, error) {
+	template, err := r.svc.VM.GetTemplateByName(ctx, info.Response.ServerInfo.Template)
+	if err != nil {
+		return types.StringNull(), err
+	}
+	return types.StringValue(strconv.Itoa(template.Id)), nil
+}
+
+// setPowerState starts or stops the VM instance, then waits for the API to report the wanted power state.
+func (r *vmInstanceResource) setPowerState(ctx context.Context, id, want string, timeout time.Duration) error {
+	var err error
+	switch want {
+	case powerStateRunning:
+		err = r.svc.VM.StartInstance(ctx, id)
+	case powerStateStopped:
+		err = r.svc.VM.StopInstance(ctx, id)
+	default:
+		err = fmt.Errorf("unsupported power state %q", want)
+	}
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		info, infoErr := r.svc.VM.GetInstanceByID(ctx, id)
+		if infoErr != nil {
+			return retry.NonRetryableError(infoErr)
+		}
+		got, ok := powerStateFromInstance(info)
+		if !ok {
+			return retry.RetryableError(fmt.Errorf("vm instance is in transition, waiting for it to be %s", want))
+		}
+		if got != want {
+			return retry.RetryableError(fmt.Errorf("vm instance is %s, waiting for it to be %s", got, want))
+		}
+		return nil
+	})
+}
+
+// resize changes the instance size of the VM instance, then waits for the API to report the new size.
+// When the API answers an error to resize a running VM instance, it is stopped, resized and left stopped.
+// It is started again when the resize is still refused, stopped is then true only if that failed too.
+func (r *vmInstanceResource) resize(ctx context.Context, id, sizeID string, timeout time.Duration) (stopped bool, err error) {
+	size, err := r.svc.VM.GetSizeByID(ctx, sizeID)
+	if err != nil {
+		return false, err
+	}
+	instanceSizeId, err := strconv.Atoi(sizeID)
+	if err != nil {
+		return false, fmt.Errorf("instance_size_id must be a numeric string: %w", err)
+	}
+
+	resizeRequest := &vm.InstanceResizeRequest{
+		VmId:           id,
+		InstanceSizeId: instanceSizeId,
+	}
+	err = r.svc.VM.ResizeInstance(ctx, resizeRequest)
+	// The error codes of the API are undocumented, so any resize of a running VM instance refused by the API
+	// is retried once stopped. Other errors, e.g. transport ones, never cause the VM instance to be stopped.
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) {
+		info, infoErr := r.svc.VM.GetInstanceByID(ctx, id)
+		if infoErr != nil {
+			return false, err
+		}
+		if powerState, _ := powerStateFromInstance(info); powerState != powerStateRunning {
+			return false, err
+		}
+		if err = r.setPowerState(ctx, id, powerStateStopped, timeout); err != nil {
+			return false, err
+		}
+		stopped = true
+		err = r.svc.VM.ResizeInstance(ctx, resizeRequest)
+		if err != nil {
+			if startErr := r.setPowerState(ctx, id, powerStateRunning, timeout); startErr != nil {
+				return true, errors.Join(err, startErr)
+			}
+			return false, err
+		}
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return stopped, retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		info, infoErr := r.svc.VM.GetInstanceByID(ctx, id)
+		if infoErr != nil {
+			return retry.NonRetryableError(infoErr)
+		}
+		if info.Response.ServerInfo.Plan != size.Name {
+			return retry.RetryableError(fmt.Errorf("vm instance size not updated yet"))
+		}
+		return nil
+	})
+}
+
+// updateSSHKeys attaches the wanted SSH keys missing from the VM instance, then detaches the other ones
+// so that access is never lost while keys are rotated. It returns the keys authorized on the VM instance
+// once done, or when failing part way.
+func (r *vmInstanceResource) updateSSHKeys(ctx context.Context, id string, current, want []string) ([]string, error) {
+	applied := slices.Clone(current)
+	for _, key := range want {
+		if slices.Contains(current, key) {
+			continue
+		}
+		err := r.svc.VM.AttachInstanceSSHKey(ctx, &vm.InstanceSSHKeyRequest{VmId: id, SshKey: key})
+		if err != nil {
+			return applied, err
+		}
+		applied = append(applied, key)
+	}
+	for _, key := range current {
+		if slices.Contains(want, key) {
+			continue
+		}
+		// The key may already be detached outside of Terraform.
+		err := r.svc.VM.DetachInstanceSSHKey(ctx, &vm.InstanceSSHKeyRequest{VmId: id, SshKey: key})
+		if err != nil && !errors.Is(err, client.ErrNotFound) {
+			return applied, err
+		}
+		applied = slices.DeleteFunc(applied, func(k string) bool { return k == key })
+	}
+	return applied, nil
+}
+
+// sameSSHKeys reports whether both lists hold the same SSH keys, in any order.
+func sameSSHKeys(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
+
+// powerStateFromInstance maps the API server state to a power_state value. It reports false
+// while the VM instance is in transition, e.g. being installed, which the API reports offline.
+func powerStateFromInstance(info *vm.InstanceReadResponse) (string, bool) {
+	if info.Response.ServerInstall {
+		return "", false
+	}
+	switch strings.ToLower(info.Response.ServerState.State) {
+	case vm.InstanceStateOnline:
+		return powerStateRunning, true
+	case vm.InstanceStateOffline:
+		return powerStateStopped, true
+	default:
+		return "", false
+	}
+}
+
+func (r *vmInstanceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan *vmInstanceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The root password is kept out of the state.
+	if !plan.StorePassword.IsUnknown() && !plan.StorePassword.ValueBool() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("password"), types.StringNull())...)
+	}
+
+	// Only a hash of the write-only user data is kept in the state.
+	var config *vmInstanceResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	userDataHashValue := types.StringUnknown()
+	if !config.UserData.IsUnknown() && !config.UserDataBase64.IsUnknown() {
+		// Invalid user data is reported by userDataValidator.
+		if userData, err := config.userData(); err == nil {
+			userDataHashValue = userDataHash(userData)
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("user_data_hash"), userDataHashValue)...)
+
+	// Nothing else to do on create, but checking that the snapshot fits on the disk and IPv6 is available.
+	if req.State.Raw.IsNull() {
+		r.modifyPlanSnapshot(ctx, plan, resp)
+		r.modifyPlanIPv6(ctx, plan, resp)
+		return
+	}
+
+	var state *vmInstanceResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// User data only runs on first boot. A VM instance without hash may have been imported,
+	// so adding or removing user data only updates the hash.
+	if !state.UserDataHash.IsNull() && !userDataHashValue.IsNull() && !userDataHashValue.Equal(state.UserDataHash) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("user_data_hash"))
+	}
+
+	// An in-place reinstall generates a new root password.
+	if plan.StorePassword.ValueBool() && plan.ReinstallOnTemplateChange.ValueBool() && !plan.TemplateId.Equal(state.TemplateId) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("password"), types.StringUnknown())...)
+	}
+
+	// A new size in another location is part of the replacement.
+	if !plan.InstanceSizeId.Equal(state.InstanceSizeId) && plan.LocationId.Equal(state.LocationId) {
+		r.modifyPlanResize(ctx, state, plan, resp)
+	}
+}
+
+// modifyPlanResize checks that the new instance size can be applied in place. The API refuses
+// to shrink the disk of a VM instance, so that change replaces it instead.
+func (r *vmInstanceResource) modifyPlanResize(ctx context.Context, state, plan *vmInstanceResourceModel, resp *resource.ModifyPlanResponse) {
+	// Sizes can only be checked once known and the provider is configured.
+	if plan.InstanceSizeId.IsUnknown() || r.svc == nil {
+		return
+	}
+
+	location, err := r.svc.VM.GetLocationByID(ctx, state.LocationId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to plan resource resize",
+			"An unexpected error occurred while attempting to read the location of the resource."+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+	current, err := r.svc.VM.GetSizeByID(ctx, state.InstanceSizeId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to plan resource resize",
+			"An unexpected error occurred while attempting to read the current size of the resource."+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+	target, err := r.svc.VM.GetSizeByID(ctx, plan.InstanceSizeId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("instance_size_id"),
+			"Invalid instance_size_id",
+			"The instance size could not be found.\n\n"+err.Error(),
+		)
+		return
+	}
+
+	targetId, _ := strconv.Atoi(target.Id)
+	if !slices.Contains(location.AvailableSizes, targetId) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("instance_size_id"),
+			"Invalid instance_size_id",
+			fmt.Sprintf("Instance size %s is not available in location %s (%s).", target.Name, location.Id, location.City),
+		)
+		return
+	}
+
+	currentSpecs, err := parseVmSizeSpecs(*current)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to plan resource resize",
+			"The current size of the resource could not be read."+
+				"Please report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+	targetSpecs, err := parseVmSizeSpecs(*target)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("instance_size_id"),
+			"Invalid instance_size_id",
+			"The instance size could not be read.\n\n"+err.Error(),
+		)
+		return
+	}
+	// The API never shrinks a disk, it is the only resize that replaces the VM instance.
+	if targetSpecs.disk < currentSpecs.disk {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("instance_size_id"))
+	}
+}
+
+// modifyPlanSnapshot checks that the VM instance can be created from the snapshot, which must
+// exist and fit on the disk of the instance size.
+func (r *vmInstanceResource) modifyPlanSnapshot(ctx context.Context, plan *vmInstanceResourceModel, resp *resource.ModifyPlanResponse) {
+	// The snapshot can only be checked once known and the provider is configured.
+	if plan.SnapshotId.IsNull() || plan.SnapshotId.IsUnknown() || plan.InstanceSizeId.IsUnknown() || r.svc == nil {
+		return
+	}
+
+	snapshot, err := r.svc.VM.GetSnapshotByID(ctx, plan.SnapshotId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("snapshot_id"),
+			"Invalid snapshot_id",
+			"The snapshot could not be found.\n\n"+err.Error(),
+		)
+		return
+	}
+	size, err := r.svc.VM.GetSizeByID(ctx, plan.InstanceSizeId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("instance_size_id"),
+			"Invalid instance_size_id",
+			"The instance size could not be found.\n\n"+err.Error(),
+		)
+		return
+	}
+
+	specs, err := parseVmSizeSpecs(*size)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("instance_size_id"),
+			"Invalid instance_size_id",
+			"The instance size could not be read.\n\n"+err.Error(),
+		)
+		return
+	}
+	if specs.disk < int64(snapshot.Size) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("instance_size_id"),
+			"Invalid instance_size_id",
+			fmt.Sprintf("Instance size %s has a %d GB disk, snapshot %s needs at least %d GB.", size.Name, specs.disk, snapshot.Name, snapshot.Size),
+		)
+	}
+}
+
+// modifyPlanIPv6 checks that IPv6 is available in the location when enable_ipv6 is true.
+func (r *vmInstanceResource) modifyPlanIPv6(ctx context.Context, plan *vmInstanceResourceModel, resp *resource.ModifyPlanResponse) {
+	// The location can only be checked once known and the provider is configured.
+	if !plan.EnableIPv6.ValueBool() || plan.LocationId.IsUnknown() || r.svc == nil {
+		return
+	}
+
+	location, err := r.svc.VM.GetLocationByID(ctx, plan.LocationId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("location_id"),
+			"Invalid location_id",
+			"The location could not be found.\n\n"+err.Error(),
+		)
+		return
+	}
+
+	if !location.SupportsIPv6() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("enable_ipv6"),
+			"Invalid enable_ipv6",
+			fmt.Sprintf("IPv6 is not available in location %s (%s).", location.Id, location.City),
+		)
+	}
+}
+
+// requiresReplaceUnlessReinstall is used on template_id so that a change is applied in place
+// when reinstall_on_template_change is enabled.
+func requiresReplaceUnlessReinstall(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	var reinstall types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("reinstall_on_template_change"), &reinstall)...)
+	resp.RequiresReplace = !reinstall.ValueBool()
+}
+
 func (r *vmInstanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
@@ -3,17 +3,20 @@ package provider
 import (
 	"context"
 	"errors"
-	"os"
+	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
-	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider"
 	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/client"
 	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/vm"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 const testAccVmInstanceResource = `
@@ -38,33 +41,473 @@ resource "oneprovider_vm_instance" "ubuntu" {
 }
 `
 
-func TestAccVmInstanceResource_removedOutOfBand(t *testing.T) {
+const testAccVmInstanceResourceResize = `
+data "oneprovider_vm_size" "selected" {name = "%s"}
+data "oneprovider_vm_location" "brussels" {city = "Brussels"}
+resource "oneprovider_vm_instance" "ubuntu" {
+	location_id      = data.oneprovider_vm_location.brussels.id
+	instance_size_id = data.oneprovider_vm_size.selected.id
+	template_id      = "1194"
+	hostname         = "ubuntu-test"
+}
+`
+
+const testAccVmInstanceResourcePowerState = `
+data "oneprovider_vm_size" "small" {name = "02d30c1"}
+data "oneprovider_vm_location" "brussels" {city = "Brussels"}
+resource "oneprovider_vm_instance" "ubuntu" {
+	location_id      = data.oneprovider_vm_location.brussels.id
+	instance_size_id = data.oneprovider_vm_size.small.id
+	template_id      = "1194"
+	hostname         = "ubuntu-test"
+	power_state      = "%s"
+}
+`
+
+func TestAccVmInstanceResource_powerState(t *testing.T) {
+	testAccSkipUnlessFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVmInstanceResource,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"oneprovider_vm_instance.ubuntu",
+						tfjsonpath.New("power_state"),
+						knownvalue.StringExact("running"),
+					),
+				},
+			},
+			{
+				Config: fmt.Sprintf(testAccVmInstanceResourcePowerState, "stopped"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"oneprovider_vm_instance.ubuntu",
+						tfjsonpath.New("power_state"),
+						knownvalue.StringExact("stopped"),
+					),
+				},
+			},
+			{
+				Config: fmt.Sprintf(testAccVmInstanceResourcePowerState, "running"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"oneprovider_vm_instance.ubuntu",
+						tfjsonpath.New("power_state"),
+						knownvalue.StringExact("running"),
+					),
+				},
+			},
+		},
+	})
+}
+
+const testAccVmInstanceResourceReinstall = `
+data "oneprovider_vm_size" "small" {name = "02d30c1"}
+data "oneprovider_vm_location" "brussels" {city = "Brussels"}
+resource "oneprovider_vm_instance" "ubuntu" {
+	location_id                  = data.oneprovider_vm_location.brussels.id
+	instance_size_id             = data.oneprovider_vm_size.small.id
+	template_id                  = "%s"
+	hostname                     = "ubuntu-test"
+	reinstall_on_template_change = true
+}
+`
+
+func TestAccVmInstanceResource_reinstall(t *testing.T) {
+	testAccSkipUnlessFakeAPI(t)
+
+	var initialID, initialIP, initialPassword string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccVmInstanceResourceReinstall, "1194"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("oneprovider_vm_instance.ubuntu", "id", func(value string) error {
+						initialID = value
+						return nil
+					}),
+					resource.TestCheckResourceAttrWith("oneprovider_vm_instance.ubuntu", "ip_address", func(value string) error {
+						initialIP = value
+						return nil
+					}),
+					resource.TestCheckResourceAttrWith("oneprovider_vm_instance.ubuntu", "password", func(value string) error {
+						initialPassword = value
+						return nil
+					}),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccVmInstanceResourceReinstall, "1108"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("oneprovider_vm_instance.ubuntu", "id", func(value string) error {
+						if value != initialID {
+							return fmt.Errorf("expected resource to be reinstalled in place, but ID changed from %s to %s", initialID, value)
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttrWith("oneprovider_vm_instance.ubuntu", "ip_address", func(value string) error {
+						if value != initialIP {
+							return fmt.Errorf("expected IP address to be kept, but it changed from %s to %s", initialIP, value)
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttrWith("oneprovider_vm_instance.ubuntu", "password", func(value string) error {
+						if value == initialPassword {
+							return fmt.Errorf("expected password to be refreshed after reinstall")
+						}
+						return nil
+					}),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"oneprovider_vm_instance.ubuntu",
+						tfjsonpath.New("template_id"),
+						knownvalue.StringExact("1108"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccVmInstanceResource_resize(t *testing.T) {
+	testAccSkipUnlessFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccVmInstanceResourceResize, "02d30c1"),
+			},
+			// A bigger size is applied in place.
+			{
+				Config: fmt.Sprintf(testAccVmInstanceResourceResize, "04d60c2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("oneprovider_vm_instance.ubuntu", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"oneprovider_vm_instance.ubuntu",
+						tfjsonpath.New("instance_size_id"),
+						knownvalue.StringExact("46"),
+					),
+					statecheck.ExpectKnownValue(
+						"oneprovider_vm_instance.ubuntu",
+						tfjsonpath.New("power_state"),
+						knownvalue.StringExact("running"),
+					),
+				},
+			},
+			// A smaller disk can't be resized in place.
+			{
+				Config: fmt.Sprintf(testAccVmInstanceResourceResize, "02d30c1"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("oneprovider_vm_instance.ubuntu", plancheck.ResourceActionReplace),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"oneprovider_vm_instance.ubuntu",
+						tfjsonpath.New("instance_size_id"),
+						knownvalue.StringExact("45"),
+					),
+				},
+			},
+		},
+	})
+}
+
+const testAccVmInstanceResourceUserData = `
+data "oneprovider_vm_size" "small" {name = "02d30c1"}
+data "oneprovider_vm_location" "brussels" {city = "Brussels"}
+resource "oneprovider_vm_instance" "ubuntu" {
+	location_id      = data.oneprovider_vm_location.brussels.id
+	instance_size_id = data.oneprovider_vm_size.small.id
+	template_id      = "1194"
+	hostname         = "ubuntu-test"
+	%s
+}
+`
+
+func TestAccVmInstanceResource_userData(t *testing.T) {
 	var vmID string
 
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      fmt.Sprintf(testAccVmInstanceResourceUserData, `user_data_base64 = "not base64"`),
+				ExpectError: regexp.MustCompile(`The value must be base64 encoded`),
+			},
+			{
+				Config: fmt.Sprintf(testAccVmInstanceResourceUserData, `user_data = "#!/bin/sh\necho hello\n"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("oneprovider_vm_instance.ubuntu", "id", func(value string) error {
+						vmID = value
+						if testAccFakeServer == nil {
+							return nil
+						}
+						if i, _ := testAccFakeServer.Instance(value); string(i.UserData) != "#!/bin/sh\necho hello\n" {
+							return fmt.Errorf("unexpected user data sent to the API: %q", i.UserData)
+						}
+						return nil
+					}),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"oneprovider_vm_instance.ubuntu",
+						tfjsonpath.New("user_data"),
+						knownvalue.Null(),
+					),
+					statecheck.ExpectKnownValue(
+						"oneprovider_vm_instance.ubuntu",
+						tfjsonpath.New("user_data_hash"),
+						knownvalue.StringExact("bfdeaeb08cffb6a36438bcd12dda25417e3cdd36f1e7e482a2849d539225288b"),
+					),
+				},
+			},
+			// The same user data, base64 encoded, is not a change.
+			{
+				Config: fmt.Sprintf(testAccVmInstanceResourceUserData, `user_data_base64 = "IyEvYmluL3NoCmVjaG8gaGVsbG8K"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				Config: fmt.Sprintf(testAccVmInstanceResourceUserData, `user_data = "#!/bin/sh\necho bye\n"`),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("oneprovider_vm_instance.ubuntu", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("oneprovider_vm_instance.ubuntu", "id", func(value string) error {
+						if value == vmID {
+							return fmt.Errorf("expected resource to be recreated, but ID remained the same: %s", value)
+						}
+						return nil
+					}),
+				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"oneprovider_vm_instance.ubuntu",
+						tfjsonpath.New("user_data_hash"),
+						knownvalue.StringExact("992e1ee5596e44c2905b529457deffa4c98e7bbbe433e848d53365ccb561afbd"),
+					),
+				},
+			},
+		},
+	})
+}
+
+const testAccVmInstanceResourceIPv6 = `
+data "oneprovider_vm_size" "small" {name = "02d30c1"}
+data "oneprovider_vm_location" "selected" {city = "%s"}
+resource "oneprovider_vm_instance" "ubuntu" {
+	location_id      = data.oneprovider_vm_location.selected.id
+	instance_size_id = data.oneprovider_vm_size.small.id
+	template_id      = "1194"
+	hostname         = "ubuntu-test"
+	enable_ipv6      = true
+}
+`
+
+func TestAccVmInstanceResource_ipv6(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
+			// Ashburn doesn't support IPv6.
+			{
+				Config:      fmt.Sprintf(testAccVmInstanceResourceIPv6, "Ashburn"),
+				ExpectError: regexp.MustCompile(`IPv6 is not available in location 171`),
+			},
+			{
+				Config: fmt.Sprintf(testAccVmInstanceResourceIPv6, "Brussels"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"oneprovider_vm_instance.ubuntu",
+						tfjsonpath.New("ipv4_address"),
+						knownvalue.StringRegexp(regexp.MustCompile(`^\d+\.\d+\.\d+\.\d+$`)),
+					),
+					statecheck.ExpectKnownValue(
+						"oneprovider_vm_instance.ubuntu",
+						tfjsonpath.New("ipv6_address"),
+						knownvalue.StringRegexp(regexp.MustCompile(`:`)),
+					),
+					statecheck.ExpectKnownValue(
+						"oneprovider_vm_instance.ubuntu",
+						tfjsonpath.New("ipv6_prefix"),
+						knownvalue.StringRegexp(regexp.MustCompile(`/\d+$`)),
+					),
+				},
+			},
+			// Disabling IPv6 replaces the VM instance.
 			{
 				Config: testAccVmInstanceResource,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("oneprovider_vm_instance.ubuntu", plancheck.ResourceActionReplace),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"oneprovider_vm_instance.ubuntu",
+						tfjsonpath.New("enable_ipv6"),
+						knownvalue.Bool(false),
+					),
+					statecheck.ExpectKnownValue(
+						"oneprovider_vm_instance.ubuntu",
+						tfjsonpath.New("ipv6_address"),
+						knownvalue.Null(),
+					),
+				},
+			},
+		},
+	})
+}
+
+const testAccVmInstanceResourceSSHKeys = `
+resource "oneprovider_ssh_key" "frodo" {
+	name       = "frodo"
+	public_key = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIMbAn3/YgZhhmsQIiGjOPOhODxpKXUo+LF3rFBvOOnYl"
+}
+resource "oneprovider_ssh_key" "sam" {
+	name       = "sam"
+	public_key = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIMbAn3/YgZhhmsQIiGjOPOhODxpKXUo+LF3rFBvOOnYw"
+}
+
+data "oneprovider_vm_size" "small" {name = "02d30c1"}
+data "oneprovider_vm_location" "brussels" {city = "Brussels"}
+resource "oneprovider_vm_instance" "ubuntu" {
+	location_id      = data.oneprovider_vm_location.brussels.id
+	instance_size_id = data.oneprovider_vm_size.small.id
+	template_id      = "1194"
+	hostname         = "ubuntu-test"
+	ssh_keys         = [%s]
+}
+`
+
+func TestAccVmInstanceResource_sshKeys(t *testing.T) {
+	testAccSkipUnlessFakeAPI(t)
+
+	var vmID, frodoID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccVmInstanceResourceSSHKeys, "oneprovider_ssh_key.frodo.id"),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttrWith("oneprovider_vm_instance.ubuntu", "id", func(value string) error {
 						vmID = value
 						return nil
 					}),
+					resource.TestCheckResourceAttrWith("oneprovider_ssh_key.frodo", "id", func(value string) error {
+						frodoID = value
+						return nil
+					}),
 				),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"oneprovider_vm_instance.ubuntu",
+						tfjsonpath.New("ssh_keys"),
+						knownvalue.ListSizeExact(1),
+					),
+				},
 			},
+			// Rotating the keys doesn't replace the VM instance.
+			{
+				Config: fmt.Sprintf(testAccVmInstanceResourceSSHKeys, "oneprovider_ssh_key.sam.id"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("oneprovider_vm_instance.ubuntu", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"oneprovider_vm_instance.ubuntu",
+						tfjsonpath.New("ssh_keys"),
+						knownvalue.ListSizeExact(1),
+					),
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("oneprovider_vm_instance.ubuntu", "id", func(value string) error {
+						if value != vmID {
+							return fmt.Errorf("expected VM instance %s to be kept, got %s", vmID, value)
+						}
+						return nil
+					}),
+				),
+			},
+			// A key attached outside of Terraform is detected and detached.
 			{
 				PreConfig: func() {
-					svc, err := oneprovider.NewService(
-						DefaultEndpoint,
-						os.Getenv(ApiKeyEnvVar),
-						os.Getenv(ClientKeyEnvVar),
-					)
+					err := testAccService(t).VM.AttachInstanceSSHKey(context.Background(), &vm.InstanceSSHKeyRequest{
+						VmId:   vmID,
+						SshKey: frodoID,
+					})
 					if err != nil {
-						t.Fatalf("failed to create service for out-of-band deletion: %v", err)
+						t.Fatalf("failed to attach the SSH key out-of-band: %v", err)
 					}
-					err = svc.VM.DestroyInstance(context.Background(), &vm.InstanceDestroyRequest{
+				},
+				Config: fmt.Sprintf(testAccVmInstanceResourceSSHKeys, "oneprovider_ssh_key.sam.id"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("oneprovider_vm_instance.ubuntu", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					func(*terraform.State) error {
+						if i, _ := testAccFakeServer.Instance(vmID); len(i.SSHKeys) != 1 || i.SSHKeys[0] == frodoID {
+							return fmt.Errorf("expected only the sam SSH key to be attached, got %v", i.SSHKeys)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccVmInstanceResource_removedOutOfBand(t *testing.T) {
+	var vmID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVmInstanceResource,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrWith("oneprovider_vm_instance.ubuntu", "id", func(value string) error {
+						vmID = value
+						return nil
+					}),
+				),
+			},
+			{
+				PreConfig: func() {
+					svc := testAccService(t)
+					err := svc.VM.DestroyInstance(context.Background(), &vm.InstanceDestroyRequest{
 						VmId:         vmID,
 						ConfirmClose: true,
 					})
@@ -72,15 +515,12 @@ func TestAccVmInstanceResource_removedOutOfBand(t *testing.T) {
 						t.Fatalf("failed to destroy VM out-of-band: %v", err)
 					}
 
-					// Poll until the API confirms the VM is gone (error 810).
+					// Poll until the API confirms the VM is gone.
 					deadline := time.Now().Add(2 * time.Minute)
 					for time.Now().Before(deadline) {
 						_, err = svc.VM.GetInstanceByID(context.Background(), vmID)
-						if err != nil {
-							var apiErr *client.APIError
-							if errors.As(err, &apiErr) && apiErr.Code == 810 {
-								break
-							}
+						if errors.Is(err, client.ErrNotFound) {
+							break
 						}
 						time.Sleep(5 * time.Second)
 					}
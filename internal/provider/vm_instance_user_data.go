@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/vm"
+)
+
+// userData returns the decoded user data configured in user_data or user_data_base64, nil when none is set.
+func (m *vmInstanceResourceModel) userData() ([]byte, error) {
+	switch {
+	case !m.UserDataBase64.IsNull():
+		return base64.StdEncoding.DecodeString(m.UserDataBase64.ValueString())
+	case !m.UserData.IsNull():
+		return []byte(m.UserData.ValueString()), nil
+	}
+	return nil, nil
+}
+
+// userDataHash returns the value of user_data_hash, which stands for the user data in the state.
+func userDataHash(data []byte) types.String {
+	if data == nil {
+		return types.StringNull()
+	}
+	sum := sha256.Sum256(data)
+	return types.StringValue(hex.EncodeToString(sum[:]))
+}
+
+var _ validator.String = userDataValidator{}
+
+// userDataValidator checks that user data can be sent to the API, see vm.EncodeUserData.
+type userDataValidator struct {
+	base64 bool
+}
+
+func (v userDataValidator) Description(ctx context.Context) string {
+	return "value must be user data accepted by the OneProvider API"
+}
+
+func (v userDataValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v userDataValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	data := []byte(req.ConfigValue.ValueString())
+	if v.base64 {
+		var err error
+		data, err = base64.StdEncoding.DecodeString(req.ConfigValue.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				req.Path,
+				"Invalid user data",
+				"The value must be base64 encoded: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if _, err := vm.EncodeUserData(data); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid user data",
+			err.Error(),
+		)
+	}
+}
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/vm"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure = &vmInstancesDataSource{}
+)
+
+type vmInstancesDataSource struct {
+	datasourceServiceInjector
+}
+
+type vmInstancesDataSourceModel struct {
+	// Input attributes (Optional/Required for filtering)
+	HostnameRegex types.String `tfsdk:"hostname_regex"`
+	City          types.String `tfsdk:"city"`
+	Plan          types.String `tfsdk:"plan"`
+	Template      types.String `tfsdk:"template"`
+	State         types.String `tfsdk:"state"`
+
+	// Output attributes (Computed)
+	Instances []vmInstancesDataSourceInstanceModel `tfsdk:"instances"`
+}
+
+type vmInstancesDataSourceInstanceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Hostname  types.String `tfsdk:"hostname"`
+	IPAddress types.String `tfsdk:"ip_address"`
+	Status    types.String `tfsdk:"status"`
+}
+
+func NewVmInstancesDataSource() datasource.DataSource {
+	return &vmInstancesDataSource{}
+}
+
+func (ds *vmInstancesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm_instances"
+}
+
+func (ds *vmInstancesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "List the VM instances of the account, optionally filtered",
+		MarkdownDescription: "List the VM instances of the account, optionally filtered",
+		Attributes: map[string]schema.Attribute{
+			// Input attributes (Optional/Required for filtering)
+			"hostname_regex": schema.StringAttribute{
+				Description: "Filter by hostname matching this regular expression",
+				Optional:    true,
+			},
+			"city": schema.StringAttribute{
+				Description: "Filter by location city",
+				Optional:    true,
+			},
+			"plan": schema.StringAttribute{
+				Description: "Filter by instance size name",
+				Optional:    true,
+			},
+			"template": schema.StringAttribute{
+				Description: "Filter by template name",
+				Optional:    true,
+			},
+			"state": schema.StringAttribute{
+				Description: "Filter by server state, either `online` or `offline`",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(vm.InstanceStateOnline, vm.InstanceStateOffline),
+				},
+			},
+			// Output attributes (Computed)
+			"instances": schema.ListNestedAttribute{
+				Description: "VM instances matching the filters, sorted as returned by the API",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "ID of the VM instance",
+							Computed:    true,
+						},
+						"hostname": schema.StringAttribute{
+							Description: "Hostname of the VM instance",
+							Computed:    true,
+						},
+						"ip_address": schema.StringAttribute{
+							Description: "IP address of the VM instance",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Status of the VM instance as reported by the API",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (ds *vmInstancesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *vmInstancesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var hostnameRegex *regexp.Regexp
+	if !data.HostnameRegex.IsNull() {
+		var err error
+		hostnameRegex, err = regexp.Compile(data.HostnameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("hostname_regex"),
+				"Invalid hostname_regex",
+				"hostname_regex must be a valid regular expression: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	list, err := ds.svc.VM.ListInstances(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to refresh datasource",
+			"An unexpected error occurred while creating the datasource read request."+
+				"Please report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+
+	data.Instances = []vmInstancesDataSourceInstanceModel{}
+	for _, i := range list.Response {
+		if hostnameRegex != nil && !hostnameRegex.MatchString(i.Hostname) {
+			continue
+		}
+		if !matchesFilter(data.City, i.City) || !matchesFilter(data.Plan, i.Plan) ||
+			!matchesFilter(data.Template, i.Template) || !matchesFilter(data.State, i.State) {
+			continue
+		}
+		data.Instances = append(data.Instances, vmInstancesDataSourceInstanceModel{
+			ID:        types.StringValue(i.Id),
+			Hostname:  types.StringValue(i.Hostname),
+			IPAddress: types.StringValue(i.IpAddress),
+			Status:    types.StringValue(i.Status),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// matchesFilter reports whether value matches an optional filter, ignoring case.
+func matchesFilter(filter types.String, value string) bool {
+	return filter.IsNull() || strings.EqualFold(filter.ValueString(), value)
+}
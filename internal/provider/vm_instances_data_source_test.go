@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+const testAccVmInstancesDataSourceConfig = `
+data "oneprovider_vm_size" "small" {name = "02d30c1"}
+data "oneprovider_vm_location" "brussels" {city = "Brussels"}
+resource "oneprovider_vm_instance" "web" {
+	location_id      = data.oneprovider_vm_location.brussels.id
+	instance_size_id = data.oneprovider_vm_size.small.id
+	template_id      = "1194"
+	hostname         = "instances-web"
+}
+resource "oneprovider_vm_instance" "db" {
+	location_id      = data.oneprovider_vm_location.brussels.id
+	instance_size_id = data.oneprovider_vm_size.small.id
+	template_id      = "1194"
+	hostname         = "instances-db"
+	power_state      = "stopped"
+}
+data "oneprovider_vm_instances" "web" {
+	hostname_regex = "^instances-w"
+	city           = "Brussels"
+	depends_on     = [oneprovider_vm_instance.web, oneprovider_vm_instance.db]
+}
+data "oneprovider_vm_instances" "offline" {
+	hostname_regex = "^instances-"
+	state          = "offline"
+	depends_on     = [oneprovider_vm_instance.web, oneprovider_vm_instance.db]
+}
+`
+
+const testAccVmInstancesDataSourceInvalidRegexConfig = `
+data "oneprovider_vm_instances" "invalid" {hostname_regex = "("}
+`
+
+func TestAccVmInstancesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVmInstancesDataSourceConfig,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.oneprovider_vm_instances.web",
+						tfjsonpath.New("instances"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"hostname":   knownvalue.StringExact("instances-web"),
+								"ip_address": knownvalue.NotNull(),
+							}),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"data.oneprovider_vm_instances.offline",
+						tfjsonpath.New("instances"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"hostname": knownvalue.StringExact("instances-db"),
+							}),
+						}),
+					),
+				},
+			},
+			{
+				Config:      testAccVmInstancesDataSourceInvalidRegexConfig,
+				ExpectError: regexp.MustCompile("Invalid hostname_regex"),
+			},
+		},
+	})
+}
@@ -73,11 +73,11 @@ func (ds *vmLocationDataSource) Schema(ctx context.Context, req datasource.Schem
 				ElementType: types.NumberType,
 			},
 			"ipv4": schema.StringAttribute{
-				Description: "Location IPv4 address",
+				Description: "IPv4 availability in the location, \"1\" when IPv4 addresses are available and \"0\" otherwise",
 				Computed:    true,
 			},
 			"ipv6": schema.StringAttribute{
-				Description: "Location IPv6 address",
+				Description: "IPv6 availability in the location, \"1\" when IPv6 addresses are available and \"0\" otherwise",
 				Computed:    true,
 			},
 		},
@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure = &vmLocationsDataSource{}
+)
+
+type vmLocationsDataSource struct {
+	datasourceServiceInjector
+}
+
+type vmLocationsDataSourceModel struct {
+	// Input attributes (Optional/Required for filtering)
+	Region        types.String `tfsdk:"region"`
+	Country       types.String `tfsdk:"country"`
+	AvailableType types.String `tfsdk:"available_type"`
+	SizeID        types.String `tfsdk:"size_id"`
+	Ipv4          types.Bool   `tfsdk:"ipv4"`
+	Ipv6          types.Bool   `tfsdk:"ipv6"`
+
+	// Output attributes (Computed)
+	Locations []vmLocationsDataSourceLocationModel `tfsdk:"locations"`
+}
+
+type vmLocationsDataSourceLocationModel struct {
+	ID             types.String `tfsdk:"id"`
+	Region         types.String `tfsdk:"region"`
+	Country        types.String `tfsdk:"country"`
+	City           types.String `tfsdk:"city"`
+	AvailableTypes types.List   `tfsdk:"available_types"`
+	AvailableSizes types.List   `tfsdk:"available_sizes"`
+	Ipv4           types.Bool   `tfsdk:"ipv4"`
+	Ipv6           types.Bool   `tfsdk:"ipv6"`
+}
+
+func NewVmLocationsDataSource() datasource.DataSource {
+	return &vmLocationsDataSource{}
+}
+
+func (ds *vmLocationsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm_locations"
+}
+
+func (ds *vmLocationsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "List the VM locations, optionally filtered by region and capabilities",
+		MarkdownDescription: "List the VM locations, optionally filtered by region and capabilities",
+		Attributes: map[string]schema.Attribute{
+			// Input attributes (Optional/Required for filtering)
+			"region": schema.StringAttribute{
+				Description: "Filter by region, e.g. `Europe`",
+				Optional:    true,
+			},
+			"country": schema.StringAttribute{
+				Description: "Filter by country code, e.g. `BE`",
+				Optional:    true,
+			},
+			"available_type": schema.StringAttribute{
+				Description: "Filter on locations offering this VM type, e.g. `High performance`",
+				Optional:    true,
+			},
+			"size_id": schema.StringAttribute{
+				Description: "Filter on locations where this instance size ID is available",
+				Optional:    true,
+			},
+			"ipv4": schema.BoolAttribute{
+				Description: "Filter on IPv4 availability",
+				Optional:    true,
+			},
+			"ipv6": schema.BoolAttribute{
+				Description: "Filter on IPv6 availability",
+				Optional:    true,
+			},
+			// Output attributes (Computed)
+			"locations": schema.ListNestedAttribute{
+				Description: "Locations matching the filters, sorted by region then city",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Location ID",
+							Computed:    true,
+						},
+						"region": schema.StringAttribute{
+							Description: "Location region",
+							Computed:    true,
+						},
+						"country": schema.StringAttribute{
+							Description: "Location country",
+							Computed:    true,
+						},
+						"city": schema.StringAttribute{
+							Description: "Location city",
+							Computed:    true,
+						},
+						"available_types": schema.ListAttribute{
+							Description: "List of available VM types",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"available_sizes": schema.ListAttribute{
+							Description: "List of available VM sizes",
+							Computed:    true,
+							ElementType: types.NumberType,
+						},
+						"ipv4": schema.BoolAttribute{
+							Description: "Whether IPv4 addresses are available. Unlike `ipv4` of the `oneprovider_vm_location` data source, it is a boolean rather than the \"1\" or \"0\" string answered by the API.",
+							Computed:    true,
+						},
+						"ipv6": schema.BoolAttribute{
+							Description: "Whether IPv6 addresses are available. Unlike `ipv6` of the `oneprovider_vm_location` data source, it is a boolean rather than the \"1\" or \"0\" string answered by the API.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (ds *vmLocationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *vmLocationsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sizeID := -1
+	if !data.SizeID.IsNull() {
+		var err error
+		sizeID, err = strconv.Atoi(data.SizeID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("size_id"),
+				"Invalid size_id",
+				"size_id must be a numeric string: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	locations, err := ds.svc.VM.ListLocations(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to refresh datasource",
+			"An unexpected error occurred while creating the datasource read request."+
+				"Please report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+
+	data.Locations = []vmLocationsDataSourceLocationModel{}
+	for _, l := range locations {
+		ipv4 := l.SupportsIPv4()
+		ipv6 := l.SupportsIPv6()
+
+		if !matchesFilter(data.Region, l.Region) || !matchesFilter(data.Country, l.Country) {
+			continue
+		}
+		if !data.AvailableType.IsNull() && !slices.ContainsFunc(l.AvailableTypes, func(t string) bool {
+			return strings.EqualFold(t, data.AvailableType.ValueString())
+		}) {
+			continue
+		}
+		if sizeID != -1 && !slices.Contains(l.AvailableSizes, sizeID) {
+			continue
+		}
+		if (!data.Ipv4.IsNull() && data.Ipv4.ValueBool() != ipv4) || (!data.Ipv6.IsNull() && data.Ipv6.ValueBool() != ipv6) {
+			continue
+		}
+
+		availableTypes, diags := types.ListValueFrom(ctx, types.StringType, l.AvailableTypes)
+		resp.Diagnostics.Append(diags...)
+		availableSizes, diags := types.ListValueFrom(ctx, types.NumberType, l.AvailableSizes)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		data.Locations = append(data.Locations, vmLocationsDataSourceLocationModel{
+			ID:             types.StringValue(l.Id),
+			Region:         types.StringValue(l.Region),
+			Country:        types.StringValue(l.Country),
+			City:           types.StringValue(l.City),
+			AvailableTypes: availableTypes,
+			AvailableSizes: availableSizes,
+			Ipv4:           types.BoolValue(ipv4),
+			Ipv6:           types.BoolValue(ipv6),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+const testAccVmLocationsDataSourceConfig = `
+data "oneprovider_vm_locations" "europe_ipv4_only" {
+	region = "Europe"
+	ipv6   = false
+}
+data "oneprovider_vm_locations" "europe_storage" {
+	region         = "Europe"
+	available_type = "Storage"
+	size_id        = "45"
+}
+`
+
+const testAccVmLocationsDataSourceInvalidSizeConfig = `
+data "oneprovider_vm_locations" "invalid" {size_id = "small"}
+`
+
+func TestAccVmLocationsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVmLocationsDataSourceConfig,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.oneprovider_vm_locations.europe_ipv4_only",
+						tfjsonpath.New("locations"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"city": knownvalue.StringExact("Dusseldorf"),
+								"ipv4": knownvalue.Bool(true),
+								"ipv6": knownvalue.Bool(false),
+							}),
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"city": knownvalue.StringExact("Nicosia"),
+							}),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"data.oneprovider_vm_locations.europe_storage",
+						tfjsonpath.New("locations"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"id":      knownvalue.StringExact("48"),
+								"city":    knownvalue.StringExact("Zurich"),
+								"country": knownvalue.StringExact("CH"),
+							}),
+						}),
+					),
+				},
+			},
+			{
+				Config:      testAccVmLocationsDataSourceInvalidSizeConfig,
+				ExpectError: regexp.MustCompile("Invalid size_id"),
+			},
+		},
+	})
+}
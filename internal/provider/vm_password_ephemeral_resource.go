@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/vm"
+)
+
+var (
+	_ ephemeral.EphemeralResourceWithConfigure = &vmPasswordEphemeralResource{}
+)
+
+type vmPasswordEphemeralResource struct {
+	ephemeralServiceInjector
+}
+
+type vmPasswordEphemeralResourceModel struct {
+	VmId     types.String `tfsdk:"vm_id"`
+	Rotate   types.Bool   `tfsdk:"rotate"`
+	Password types.String `tfsdk:"password"`
+}
+
+func NewVmPasswordEphemeralResource() ephemeral.EphemeralResource {
+	return &vmPasswordEphemeralResource{}
+}
+
+func (e *vmPasswordEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm_password"
+}
+
+func (e *vmPasswordEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reset the root password of a VM instance and return the new one without storing it in the state. " +
+			"The API never returns the current password, so the password is only reset when `rotate` is true. " +
+			"Terraform opens ephemeral resources during both plan and apply: a plan with `rotate` set to true resets " +
+			"the root password of the VM instance too and the password it gets is thrown away.",
+		MarkdownDescription: "Reset the root password of a VM instance and return the new one without storing it in the state. " +
+			"The API never returns the current password, so the password is only reset when `rotate` is true. " +
+			"Terraform opens ephemeral resources during both plan and apply: a plan with `rotate` set to true resets " +
+			"the root password of the VM instance too and the password it gets is thrown away.",
+		Attributes: map[string]schema.Attribute{
+			"vm_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the VM instance.",
+				Required:            true,
+			},
+			"rotate": schema.BoolAttribute{
+				MarkdownDescription: "Reset the root password. Set it from an input variable only true when rotating, " +
+					"e.g. `terraform apply -var rotate_root_password=true`. Defaults to false.",
+				Optional: true,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "New password of the root user, null unless `rotate` is true.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (e *vmPasswordEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data vmPasswordEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Opening the ephemeral resource must not change the VM instance unless asked to.
+	if !data.Rotate.ValueBool() {
+		data.Password = types.StringNull()
+		resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+		return
+	}
+
+	reset, err := e.svc.VM.ResetInstancePassword(ctx, &vm.InstancePasswordResetRequest{
+		VmId: data.VmId.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to open ephemeral resource",
+			"An unexpected error occurred while attempting to reset the root password of the VM instance."+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+
+	data.Password = types.StringValue(reset.Response.Password)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
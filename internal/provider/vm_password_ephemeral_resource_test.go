@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+const testAccVmPasswordEphemeralResourceInstance = `
+data "oneprovider_vm_size" "small" {name = "02d30c1"}
+data "oneprovider_vm_location" "brussels" {city = "Brussels"}
+resource "oneprovider_vm_instance" "ubuntu" {
+	location_id      = data.oneprovider_vm_location.brussels.id
+	instance_size_id = data.oneprovider_vm_size.small.id
+	template_id      = "1194"
+	hostname         = "ubuntu-test"
+	store_password   = %t
+}
+`
+
+const testAccVmPasswordEphemeralResource = `
+ephemeral "oneprovider_vm_password" "root" {
+	vm_id  = oneprovider_vm_instance.ubuntu.id
+	rotate = %t
+}
+
+provider "echo" {
+	data = ephemeral.oneprovider_vm_password.root
+}
+
+resource "echo" "password" {}
+`
+
+func TestAccVmPasswordEphemeralResource(t *testing.T) {
+	testAccSkipUnlessFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"oneprovider": testAccProtoV6ProviderFactories["oneprovider"],
+			"echo":        echoprovider.NewProviderServer(),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccVmPasswordEphemeralResourceInstance, true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"oneprovider_vm_instance.ubuntu",
+						tfjsonpath.New("password"),
+						knownvalue.NotNull(),
+					),
+				},
+			},
+			// Dropping the password from the state doesn't replace the VM instance, nor does opening
+			// the ephemeral resource reset the password unless asked to.
+			{
+				Config: fmt.Sprintf(testAccVmPasswordEphemeralResourceInstance, false) + fmt.Sprintf(testAccVmPasswordEphemeralResource, false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("oneprovider_vm_instance.ubuntu", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"oneprovider_vm_instance.ubuntu",
+						tfjsonpath.New("password"),
+						knownvalue.Null(),
+					),
+					statecheck.ExpectKnownValue(
+						"echo.password",
+						tfjsonpath.New("data").AtMapKey("password"),
+						knownvalue.Null(),
+					),
+				},
+			},
+			{
+				Config: fmt.Sprintf(testAccVmPasswordEphemeralResourceInstance, false) + fmt.Sprintf(testAccVmPasswordEphemeralResource, true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"echo.password",
+						tfjsonpath.New("data").AtMapKey("password"),
+						knownvalue.StringRegexp(regexp.MustCompile(`.+`)),
+					),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/vm"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure = &vmSizesDataSource{}
+)
+
+type vmSizesDataSource struct {
+	datasourceServiceInjector
+}
+
+type vmSizesDataSourceModel struct {
+	// Input attributes (Optional/Required for filtering)
+	Type       types.String `tfsdk:"type"`
+	LocationId types.String `tfsdk:"location_id"`
+	MinCores   types.Int64  `tfsdk:"min_cores"`
+	MinRAMMB   types.Int64  `tfsdk:"min_ram_mb"`
+	MinDiskGB  types.Int64  `tfsdk:"min_disk_gb"`
+	Smallest   types.Bool   `tfsdk:"smallest"`
+
+	// Output attributes (Computed)
+	Sizes []vmSizesDataSourceSizeModel `tfsdk:"sizes"`
+}
+
+type vmSizesDataSourceSizeModel struct {
+	ID     types.String `tfsdk:"id"`
+	Name   types.String `tfsdk:"name"`
+	Type   types.String `tfsdk:"type"`
+	Cores  types.Int64  `tfsdk:"cores"`
+	RAMMB  types.Int64  `tfsdk:"ram_mb"`
+	DiskGB types.Int64  `tfsdk:"disk_gb"`
+}
+
+// vmSizeSpecs is a VM size with its numeric specs parsed.
+type vmSizeSpecs struct {
+	vm.SizeReadResponse
+	cores, ram, disk int64
+}
+
+func NewVmSizesDataSource() datasource.DataSource {
+	return &vmSizesDataSource{}
+}
+
+func (ds *vmSizesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm_sizes"
+}
+
+func (ds *vmSizesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "List the VM sizes matching hardware requirements, smallest first",
+		MarkdownDescription: "List the VM sizes matching hardware requirements, smallest first",
+		Attributes: map[string]schema.Attribute{
+			// Input attributes (Optional/Required for filtering)
+			"type": schema.StringAttribute{
+				Description: "Filter by type definition, e.g. `General Purpose`",
+				Optional:    true,
+			},
+			"location_id": schema.StringAttribute{
+				Description: "Filter on sizes available in this location ID",
+				Optional:    true,
+			},
+			"min_cores": schema.Int64Attribute{
+				Description: "Minimum number of CPU cores",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"min_ram_mb": schema.Int64Attribute{
+				Description: "Minimum RAM in MB",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"min_disk_gb": schema.Int64Attribute{
+				Description: "Minimum disk storage size in GB",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"smallest": schema.BoolAttribute{
+				Description: "Only return the smallest matching size. The API does not expose prices, so this is the cheapest match in practice.",
+				Optional:    true,
+			},
+			// Output attributes (Computed)
+			"sizes": schema.ListNestedAttribute{
+				Description: "Sizes matching the filters, sorted by cores, RAM then disk",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "Size ID",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Size name",
+							Computed:    true,
+						},
+						"type": schema.StringAttribute{
+							Description: "Type definition",
+							Computed:    true,
+						},
+						"cores": schema.Int64Attribute{
+							Description: "Number of CPU core available on the VM",
+							Computed:    true,
+						},
+						"ram_mb": schema.Int64Attribute{
+							Description: "RAM available in MB",
+							Computed:    true,
+						},
+						"disk_gb": schema.Int64Attribute{
+							Description: "Disk storage size in GB",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (ds *vmSizesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *vmSizesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A location reporting no sizes matches none of them.
+	filterByLocation := !data.LocationId.IsNull()
+	var availableSizes []int
+	if filterByLocation {
+		l, err := ds.svc.VM.GetLocationByID(ctx, data.LocationId.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to refresh datasource",
+				"An unexpected error occurred while creating the datasource read request."+
+					"Please report this issue to the provider developers.\n\n"+
+					err.Error(),
+			)
+			return
+		}
+		availableSizes = l.AvailableSizes
+	}
+
+	sizes, err := ds.svc.VM.ListSizes(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to refresh datasource",
+			"An unexpected error occurred while creating the datasource read request."+
+				"Please report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+
+	var matches []vmSizeSpecs
+	for _, s := range sizes {
+		specs, err := parseVmSizeSpecs(s)
+		if err != nil {
+			// A single malformed size must not hide the other ones.
+			resp.Diagnostics.AddWarning(
+				"Instance size skipped",
+				"The API returned a size with malformed specs, it was left out of the results. "+
+					"Please report this issue to the provider developers.\n\n"+
+					err.Error(),
+			)
+			continue
+		}
+
+		if !matchesFilter(data.Type, s.Type) {
+			continue
+		}
+		if filterByLocation {
+			id, _ := strconv.Atoi(s.Id)
+			if !slices.Contains(availableSizes, id) {
+				continue
+			}
+		}
+		if specs.cores < data.MinCores.ValueInt64() || specs.ram < data.MinRAMMB.ValueInt64() || specs.disk < data.MinDiskGB.ValueInt64() {
+			continue
+		}
+		matches = append(matches, specs)
+	}
+
+	slices.SortStableFunc(matches, func(a, b vmSizeSpecs) int {
+		return cmp.Or(cmp.Compare(a.cores, b.cores), cmp.Compare(a.ram, b.ram), cmp.Compare(a.disk, b.disk))
+	})
+	if data.Smallest.ValueBool() && len(matches) > 1 {
+		matches = matches[:1]
+	}
+
+	data.Sizes = []vmSizesDataSourceSizeModel{}
+	for _, s := range matches {
+		data.Sizes = append(data.Sizes, vmSizesDataSourceSizeModel{
+			ID:     types.StringValue(s.Id),
+			Name:   types.StringValue(s.Name),
+			Type:   types.StringValue(s.Type),
+			Cores:  types.Int64Value(s.cores),
+			RAMMB:  types.Int64Value(s.ram),
+			DiskGB: types.Int64Value(s.disk),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// parseVmSizeSpecs converts the string specs returned by the API to numbers.
+func parseVmSizeSpecs(s vm.SizeReadResponse) (vmSizeSpecs, error) {
+	specs := vmSizeSpecs{SizeReadResponse: s}
+	var err error
+	if specs.cores, err = strconv.ParseInt(s.Cores, 10, 64); err != nil {
+		return specs, fmt.Errorf("size %s: invalid cores %q: %w", s.Id, s.Cores, err)
+	}
+	if specs.ram, err = strconv.ParseInt(s.RAM, 10, 64); err != nil {
+		return specs, fmt.Errorf("size %s: invalid ram %q: %w", s.Id, s.RAM, err)
+	}
+	if specs.disk, err = strconv.ParseInt(s.Disk, 10, 64); err != nil {
+		return specs, fmt.Errorf("size %s: invalid disk %q: %w", s.Id, s.Disk, err)
+	}
+	return specs, nil
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+const testAccVmSizesDataSourceConfig = `
+data "oneprovider_vm_location" "brussels" {city = "Brussels"}
+data "oneprovider_vm_sizes" "big_disk" {
+	location_id = data.oneprovider_vm_location.brussels.id
+	min_disk_gb = 100
+}
+data "oneprovider_vm_sizes" "washington" {
+	location_id = "23"
+}
+data "oneprovider_vm_sizes" "cheapest_hp" {
+	type      = "High performance"
+	min_cores = 4
+	smallest  = true
+}
+`
+
+func TestAccVmSizesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVmSizesDataSourceConfig,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.oneprovider_vm_sizes.big_disk",
+						tfjsonpath.New("sizes"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{"id": knownvalue.StringExact("47")}),
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{"id": knownvalue.StringExact("48")}),
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{"id": knownvalue.StringExact("56")}),
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{"id": knownvalue.StringExact("49")}),
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{"id": knownvalue.StringExact("50")}),
+						}),
+					),
+					// Washington reports no available sizes.
+					statecheck.ExpectKnownValue(
+						"data.oneprovider_vm_sizes.washington",
+						tfjsonpath.New("sizes"),
+						knownvalue.ListSizeExact(0),
+					),
+					statecheck.ExpectKnownValue(
+						"data.oneprovider_vm_sizes.cheapest_hp",
+						tfjsonpath.New("sizes"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectExact(map[string]knownvalue.Check{
+								"id":      knownvalue.StringExact("55"),
+								"name":    knownvalue.StringExact("04d80c4-hp"),
+								"type":    knownvalue.StringExact("High performance"),
+								"cores":   knownvalue.Int64Exact(4),
+								"ram_mb":  knownvalue.Int64Exact(4096),
+								"disk_gb": knownvalue.Int64Exact(80),
+							}),
+						}),
+					),
+				},
+			},
+		},
+	})
+}
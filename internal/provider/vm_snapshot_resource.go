@@ -0,0 +1,250 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/client"
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/vm"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+var (
+	_ resource.Resource                = &vmSnapshotResource{}
+	_ resource.ResourceWithConfigure   = &vmSnapshotResource{}
+	_ resource.ResourceWithImportState = &vmSnapshotResource{}
+)
+
+type vmSnapshotResource struct {
+	resourceServiceInjector
+}
+
+type vmSnapshotResourceModel struct {
+	ID        types.String   `tfsdk:"id"`
+	VmId      types.String   `tfsdk:"vm_id"`
+	Name      types.String   `tfsdk:"name"`
+	Size      types.Int64    `tfsdk:"size"`
+	Status    types.String   `tfsdk:"status"`
+	CreatedAt types.String   `tfsdk:"created_at"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewVmSnapshotResource() resource.Resource {
+	return &vmSnapshotResource{}
+}
+
+func (r *vmSnapshotResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm_snapshot"
+}
+
+func (r *vmSnapshotResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Take a snapshot of the disk of a VM instance. The snapshot outlives the VM instance, " +
+			"so it can be used as a golden image with the `snapshot_id` argument of `oneprovider_vm_instance`.",
+		MarkdownDescription: "Take a snapshot of the disk of a VM instance. The snapshot outlives the VM instance, " +
+			"so it can be used as a golden image with the `snapshot_id` argument of `oneprovider_vm_instance`.",
+		Attributes: map[string]schema.Attribute{
+			// Inputs
+			"vm_id": schema.StringAttribute{
+				Description: "ID of the VM instance to take the snapshot of. The VM instance must be installed.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "Name of the snapshot",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			// Outputs
+			"id": schema.StringAttribute{
+				Description: "ID of the snapshot",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"size": schema.Int64Attribute{
+				Description: "Size of the snapshot in GB. VM instances created from it need a disk at least as large.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of the snapshot, `available` once created",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				Description: "Creation date of the snapshot as reported by the API",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+			}),
+		},
+	}
+}
+
+func (r *vmSnapshotResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data *vmSnapshotResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.svc.VM.CreateSnapshot(ctx, &vm.SnapshotCreateRequest{
+		VmId: data.VmId.ValueString(),
+		Name: data.Name.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create resource",
+			"An unexpected error occurred while attempting to create the resource."+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+	data.ID = types.StringValue(created.Response.Id)
+
+	createTimeout, diags := data.Timeouts.Create(ctx, 10*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshot, err := r.waitForAvailable(ctx, data.ID.ValueString(), createTimeout)
+	if err != nil {
+		// The snapshot exists at this point, keep it in the state so it's not leaked.
+		data.Size = types.Int64Null()
+		data.Status = types.StringValue(vm.SnapshotStatusCreating)
+		data.CreatedAt = types.StringNull()
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		resp.Diagnostics.AddError(
+			"Unable to refresh resource",
+			"The snapshot was requested but did not become available."+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+	data.setSnapshot(snapshot)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *vmSnapshotResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *vmSnapshotResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshot, err := r.svc.VM.GetSnapshotByID(ctx, data.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to refresh resource",
+			"An unexpected error occurred while attempting to refresh the resource."+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+	data.setSnapshot(snapshot)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update only stores the timeouts, every other argument replaces the snapshot.
+func (r *vmSnapshotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data *vmSnapshotResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *vmSnapshotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data *vmSnapshotResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.svc.VM.DeleteSnapshot(ctx, data.ID.ValueString())
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError(
+			"Unable to destroy resource",
+			"An unexpected error occurred while attempting to destroy the resource."+
+				"Please retry the operation or report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+}
+
+func (r *vmSnapshotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// waitForAvailable waits for the snapshot to be created.
+func (r *vmSnapshotResource) waitForAvailable(ctx context.Context, id string, timeout time.Duration) (*vm.SnapshotReadResponse, error) {
+	var snapshot *vm.SnapshotReadResponse
+	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		var getErr error
+		snapshot, getErr = r.svc.VM.GetSnapshotByID(ctx, id)
+		if getErr != nil {
+			return retry.NonRetryableError(getErr)
+		}
+		if snapshot.Status != vm.SnapshotStatusAvailable {
+			return retry.RetryableError(fmt.Errorf("vm snapshot is %s, waiting for it to be available", snapshot.Status))
+		}
+		return nil
+	})
+	return snapshot, err
+}
+
+func (m *vmSnapshotResourceModel) setSnapshot(snapshot *vm.SnapshotReadResponse) {
+	m.VmId = types.StringValue(snapshot.VmId)
+	m.Name = types.StringValue(snapshot.Name)
+	m.Size = types.Int64Value(int64(snapshot.Size))
+	m.Status = types.StringValue(snapshot.Status)
+	m.CreatedAt = types.StringValue(snapshot.CreatedAt)
+}
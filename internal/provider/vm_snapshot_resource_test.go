@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/compare"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+const testAccVmSnapshotResource = `
+data "oneprovider_vm_size" "small" {name = "02d30c1"}
+data "oneprovider_vm_location" "brussels" {city = "Brussels"}
+resource "oneprovider_vm_instance" "golden" {
+	location_id      = data.oneprovider_vm_location.brussels.id
+	instance_size_id = data.oneprovider_vm_size.small.id
+	template_id      = "1194"
+	hostname         = "golden"
+}
+
+resource "oneprovider_vm_snapshot" "golden" {
+	vm_id = oneprovider_vm_instance.golden.id
+	name  = "golden-image"
+}
+`
+
+const testAccVmSnapshotResourceClone = `
+resource "oneprovider_vm_instance" "clone" {
+	location_id      = data.oneprovider_vm_location.brussels.id
+	instance_size_id = data.oneprovider_vm_size.small.id
+	snapshot_id      = oneprovider_vm_snapshot.golden.id
+	hostname         = "clone"
+}
+`
+
+const testAccVmSnapshotResourceInvalidClone = `
+resource "oneprovider_vm_instance" "clone" {
+	location_id      = data.oneprovider_vm_location.brussels.id
+	instance_size_id = data.oneprovider_vm_size.small.id
+	template_id      = "1194"
+	snapshot_id      = oneprovider_vm_snapshot.golden.id
+	hostname         = "clone"
+}
+`
+
+func TestAccVmSnapshotResource(t *testing.T) {
+	testAccSkipUnlessFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVmSnapshotResource,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.CompareValuePairs(
+						"oneprovider_vm_snapshot.golden",
+						tfjsonpath.New("vm_id"),
+						"oneprovider_vm_instance.golden",
+						tfjsonpath.New("id"),
+						compare.ValuesSame(),
+					),
+					statecheck.ExpectKnownValue(
+						"oneprovider_vm_snapshot.golden",
+						tfjsonpath.New("status"),
+						knownvalue.StringExact("available"),
+					),
+					statecheck.ExpectKnownValue(
+						"oneprovider_vm_snapshot.golden",
+						tfjsonpath.New("size"),
+						knownvalue.NotNull(),
+					),
+				},
+			},
+			{
+				ResourceName:      "oneprovider_vm_snapshot.golden",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:      testAccVmSnapshotResource + testAccVmSnapshotResourceInvalidClone,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			// The clone gets the template of the snapshot.
+			{
+				Config: testAccVmSnapshotResource + testAccVmSnapshotResourceClone,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"oneprovider_vm_instance.clone",
+						tfjsonpath.New("template_id"),
+						knownvalue.StringExact("1194"),
+					),
+					statecheck.CompareValuePairs(
+						"oneprovider_vm_instance.clone",
+						tfjsonpath.New("snapshot_id"),
+						"oneprovider_vm_snapshot.golden",
+						tfjsonpath.New("id"),
+						compare.ValuesSame(),
+					),
+				},
+			},
+		},
+	})
+}
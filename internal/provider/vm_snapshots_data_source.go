@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/vm"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure = &vmSnapshotsDataSource{}
+)
+
+type vmSnapshotsDataSource struct {
+	datasourceServiceInjector
+}
+
+type vmSnapshotsDataSourceModel struct {
+	// Input attributes (Optional/Required for filtering)
+	VmId      types.String `tfsdk:"vm_id"`
+	NameRegex types.String `tfsdk:"name_regex"`
+	Status    types.String `tfsdk:"status"`
+
+	// Output attributes (Computed)
+	Snapshots []vmSnapshotsDataSourceSnapshotModel `tfsdk:"snapshots"`
+}
+
+type vmSnapshotsDataSourceSnapshotModel struct {
+	ID        types.String `tfsdk:"id"`
+	VmId      types.String `tfsdk:"vm_id"`
+	Name      types.String `tfsdk:"name"`
+	Size      types.Int64  `tfsdk:"size"`
+	Status    types.String `tfsdk:"status"`
+	CreatedAt types.String `tfsdk:"created_at"`
+}
+
+func NewVmSnapshotsDataSource() datasource.DataSource {
+	return &vmSnapshotsDataSource{}
+}
+
+func (ds *vmSnapshotsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm_snapshots"
+}
+
+func (ds *vmSnapshotsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "List the VM snapshots of the account, optionally filtered",
+		MarkdownDescription: "List the VM snapshots of the account, optionally filtered",
+		Attributes: map[string]schema.Attribute{
+			// Input attributes (Optional/Required for filtering)
+			"vm_id": schema.StringAttribute{
+				Description: "Filter by ID of the VM instance the snapshot was taken of",
+				Optional:    true,
+			},
+			"name_regex": schema.StringAttribute{
+				Description: "Filter by name matching this regular expression",
+				Optional:    true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Filter by status, either `creating` or `available`",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(vm.SnapshotStatusCreating, vm.SnapshotStatusAvailable),
+				},
+			},
+			// Output attributes (Computed)
+			"snapshots": schema.ListNestedAttribute{
+				Description: "VM snapshots matching the filters, sorted as returned by the API",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "ID of the snapshot",
+							Computed:    true,
+						},
+						"vm_id": schema.StringAttribute{
+							Description: "ID of the VM instance the snapshot was taken of",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the snapshot",
+							Computed:    true,
+						},
+						"size": schema.Int64Attribute{
+							Description: "Size of the snapshot in GB",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Status of the snapshot",
+							Computed:    true,
+						},
+						"created_at": schema.StringAttribute{
+							Description: "Creation date of the snapshot as reported by the API",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (ds *vmSnapshotsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *vmSnapshotsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid name_regex",
+				"name_regex must be a valid regular expression: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	snapshots, err := ds.svc.VM.ListSnapshots(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to refresh datasource",
+			"An unexpected error occurred while creating the datasource read request."+
+				"Please report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return
+	}
+
+	data.Snapshots = []vmSnapshotsDataSourceSnapshotModel{}
+	for _, s := range snapshots {
+		if nameRegex != nil && !nameRegex.MatchString(s.Name) {
+			continue
+		}
+		if !data.VmId.IsNull() && data.VmId.ValueString() != s.VmId {
+			continue
+		}
+		if !matchesFilter(data.Status, s.Status) {
+			continue
+		}
+		data.Snapshots = append(data.Snapshots, vmSnapshotsDataSourceSnapshotModel{
+			ID:        types.StringValue(s.Id),
+			VmId:      types.StringValue(s.VmId),
+			Name:      types.StringValue(s.Name),
+			Size:      types.Int64Value(int64(s.Size)),
+			Status:    types.StringValue(s.Status),
+			CreatedAt: types.StringValue(s.CreatedAt),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+const testAccVmSnapshotsDataSourceConfig = `
+data "oneprovider_vm_size" "small" {name = "02d30c1"}
+data "oneprovider_vm_location" "brussels" {city = "Brussels"}
+resource "oneprovider_vm_instance" "web" {
+	location_id      = data.oneprovider_vm_location.brussels.id
+	instance_size_id = data.oneprovider_vm_size.small.id
+	template_id      = "1194"
+	hostname         = "snapshots-web"
+}
+resource "oneprovider_vm_snapshot" "before" {
+	vm_id = oneprovider_vm_instance.web.id
+	name  = "snapshots-before-upgrade"
+}
+resource "oneprovider_vm_snapshot" "nightly" {
+	vm_id = oneprovider_vm_instance.web.id
+	name  = "snapshots-nightly"
+}
+data "oneprovider_vm_snapshots" "nightly" {
+	vm_id      = oneprovider_vm_instance.web.id
+	name_regex = "nightly$"
+	depends_on = [oneprovider_vm_snapshot.before, oneprovider_vm_snapshot.nightly]
+}
+`
+
+const testAccVmSnapshotsDataSourceInvalidRegexConfig = `
+data "oneprovider_vm_snapshots" "invalid" {name_regex = "("}
+`
+
+func TestAccVmSnapshotsDataSource(t *testing.T) {
+	testAccSkipUnlessFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVmSnapshotsDataSourceConfig,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.oneprovider_vm_snapshots.nightly",
+						tfjsonpath.New("snapshots"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"name":   knownvalue.StringExact("snapshots-nightly"),
+								"status": knownvalue.StringExact("available"),
+							}),
+						}),
+					),
+				},
+			},
+			{
+				Config:      testAccVmSnapshotsDataSourceInvalidRegexConfig,
+				ExpectError: regexp.MustCompile("Invalid name_regex"),
+			},
+		},
+	})
+}
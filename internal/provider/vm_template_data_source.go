@@ -2,11 +2,16 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"strconv"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -19,9 +24,12 @@ type vmTemplateDataSource struct {
 }
 
 type vmTemplateDataSourceModel struct {
-	ID   types.String `tfsdk:"id"`
-	Name types.String `tfsdk:"name"`
-	Size types.String `tfsdk:"size"`
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Family     types.String `tfsdk:"family"`
+	Version    types.String `tfsdk:"version"`
+	MostRecent types.Bool   `tfsdk:"most_recent"`
+	Size       types.String `tfsdk:"size"`
 }
 
 func NewVmTemplateDataSource() datasource.DataSource {
@@ -34,13 +42,35 @@ func (ds *vmTemplateDataSource) Metadata(ctx context.Context, request datasource
 
 func (ds *vmTemplateDataSource) Schema(ctx context.Context, request datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description:         "Fetches a VM template by its name.",
-		MarkdownDescription: "Fetches a VM template by its name.",
+		Description:         "Fetches a VM template by its name, or by its OS family and version.",
+		MarkdownDescription: "Fetches a VM template by its name, or by its OS family and version.",
 		Attributes: map[string]schema.Attribute{
 			// Inputs
 			"name": schema.StringAttribute{
-				Description: "Name of the template.",
-				Required:    true,
+				Description: "Name of the template. Exactly one of `name` or `family` must be set.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("family")),
+				},
+			},
+			"family": schema.StringAttribute{
+				Description: "OS family of the template, e.g. `ubuntu` or `debian`.",
+				Optional:    true,
+			},
+			"version": schema.StringAttribute{
+				Description: "Version constraint on the OS version parsed from the template name, e.g. `>= 12` or `~> 24.04`. Requires `family`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("family")),
+				},
+			},
+			"most_recent": schema.BoolAttribute{
+				Description: "Pick the most recent version when several templates of `family` match. Without it, several matches are an error. Requires `family`.",
+				Optional:    true,
+				Validators: []validator.Bool{
+					boolvalidator.AlsoRequires(path.MatchRoot("family")),
+				},
 			},
 			// Outputs
 			"id": schema.StringAttribute{
@@ -63,6 +93,11 @@ func (ds *vmTemplateDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
+	if !data.Family.IsNull() {
+		ds.readByFamily(ctx, data, resp)
+		return
+	}
+
 	name := data.Name.ValueString()
 	if name == "" {
 		resp.Diagnostics.AddAttributeError(
@@ -89,3 +124,40 @@ func (ds *vmTemplateDataSource) Read(ctx context.Context, req datasource.ReadReq
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// readByFamily looks the template up by OS family and version constraint.
+func (ds *vmTemplateDataSource) readByFamily(ctx context.Context, data *vmTemplateDataSourceModel, resp *datasource.ReadResponse) {
+	matches, diags := matchTemplates(ctx, ds.svc.VM, data.Family, data.Version)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case len(matches) == 0:
+		resp.Diagnostics.AddError(
+			"No template found",
+			fmt.Sprintf("No template of family %q matches version %q.", data.Family.ValueString(), data.Version.ValueString()),
+		)
+		return
+	case len(matches) > 1 && !data.MostRecent.ValueBool():
+		names := make([]string, 0, len(matches))
+		for _, m := range matches {
+			names = append(names, m.Name)
+		}
+		resp.Diagnostics.AddError(
+			"Multiple templates found",
+			fmt.Sprintf("%d templates of family %q match: %s. Narrow down version or set most_recent to true.",
+				len(matches), data.Family.ValueString(), strings.Join(names, ", ")),
+		)
+		return
+	}
+
+	// Matches are sorted most recent first.
+	tpl := matches[0]
+	data.ID = types.StringValue(strconv.Itoa(tpl.Id))
+	data.Name = types.StringValue(tpl.Name)
+	data.Size = types.StringValue(tpl.Size)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -14,6 +14,57 @@ const testAccVMTemplateDataSourceConfig = `data "oneprovider_vm_template" "ubunt
 
 const testAccVMTemplateDontExistDataSourceConfig = `data "oneprovider_vm_template" "ubuntu" { name = "random-name-that-does-not-exist" }`
 
+const testAccVMTemplateFamilyDataSourceConfig = `
+data "oneprovider_vm_template" "latest_ubuntu" {
+	family      = "ubuntu"
+	most_recent = true
+}
+data "oneprovider_vm_template" "debian_12" {
+	family  = "debian"
+	version = "= 12"
+}
+`
+
+const testAccVMTemplateFamilyAmbiguousDataSourceConfig = `
+data "oneprovider_vm_template" "debian" {
+	family  = "debian"
+	version = ">= 10, < 12"
+}
+`
+
+func TestAccVMTemplateDataSource_family(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVMTemplateFamilyDataSourceConfig,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.oneprovider_vm_template.latest_ubuntu",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact("1194"),
+					),
+					statecheck.ExpectKnownValue(
+						"data.oneprovider_vm_template.latest_ubuntu",
+						tfjsonpath.New("name"),
+						knownvalue.StringExact("Ubuntu 24.04.3 64bits"),
+					),
+					statecheck.ExpectKnownValue(
+						"data.oneprovider_vm_template.debian_12",
+						tfjsonpath.New("id"),
+						knownvalue.StringExact("1055"),
+					),
+				},
+			},
+			{
+				Config:      testAccVMTemplateFamilyAmbiguousDataSourceConfig,
+				ExpectError: regexp.MustCompile("Multiple templates found"),
+			},
+		},
+	})
+}
+
 func TestAccVMTemplateDataSource(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
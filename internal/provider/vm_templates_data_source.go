@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/vm"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure = &vmTemplatesDataSource{}
+)
+
+type vmTemplatesDataSource struct {
+	datasourceServiceInjector
+}
+
+type vmTemplatesDataSourceModel struct {
+	// Input attributes (Optional/Required for filtering)
+	Family  types.String `tfsdk:"family"`
+	Version types.String `tfsdk:"version"`
+
+	// Output attributes (Computed)
+	Templates []vmTemplatesDataSourceTemplateModel `tfsdk:"templates"`
+}
+
+type vmTemplatesDataSourceTemplateModel struct {
+	ID      types.String `tfsdk:"id"`
+	Name    types.String `tfsdk:"name"`
+	Family  types.String `tfsdk:"family"`
+	Version types.String `tfsdk:"version"`
+	Size    types.String `tfsdk:"size"`
+}
+
+// vmTemplateMatch is a VM template with its parsed version, nil when the name has none.
+type vmTemplateMatch struct {
+	vm.TemplateReadResponse
+	version *version.Version
+}
+
+func NewVmTemplatesDataSource() datasource.DataSource {
+	return &vmTemplatesDataSource{}
+}
+
+func (ds *vmTemplatesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vm_templates"
+}
+
+func (ds *vmTemplatesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Lists VM templates, optionally filtered by OS family and version.",
+		MarkdownDescription: "Lists VM templates, optionally filtered by OS family and version.",
+		Attributes: map[string]schema.Attribute{
+			// Inputs
+			"family": schema.StringAttribute{
+				Description: "OS family of the templates, e.g. `ubuntu` or `debian`.",
+				Optional:    true,
+			},
+			"version": schema.StringAttribute{
+				Description: "Version constraint on the OS version parsed from the template name, e.g. `>= 12` or `~> 24.04`.",
+				Optional:    true,
+			},
+			// Outputs
+			"templates": schema.ListNestedAttribute{
+				Description: "Templates matching the filters, sorted by family then most recent version first.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "ID of the template.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "Name of the template.",
+							Computed:    true,
+						},
+						"family": schema.StringAttribute{
+							Description: "OS family of the template.",
+							Computed:    true,
+						},
+						"version": schema.StringAttribute{
+							Description: "OS version parsed from the template name, empty when it has none.",
+							Computed:    true,
+						},
+						"size": schema.StringAttribute{
+							Description: "Size of the template in bytes.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (ds *vmTemplatesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *vmTemplatesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	matches, diags := matchTemplates(ctx, ds.svc.VM, data.Family, data.Version)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Templates = []vmTemplatesDataSourceTemplateModel{}
+	for _, m := range matches {
+		v := ""
+		if m.version != nil {
+			v = m.version.Original()
+		}
+		data.Templates = append(data.Templates, vmTemplatesDataSourceTemplateModel{
+			ID:      types.StringValue(strconv.Itoa(m.Id)),
+			Name:    types.StringValue(m.Name),
+			Family:  types.StringValue(m.Family()),
+			Version: types.StringValue(v),
+			Size:    types.StringValue(m.Size),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// matchTemplates lists the templates of the optional family whose version satisfies the optional
+// constraint, sorted by family then most recent version first. It is shared with oneprovider_vm_template.
+func matchTemplates(ctx context.Context, svc *vm.Service, family, constraint types.String) ([]vmTemplateMatch, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var constraints version.Constraints
+	if !constraint.IsNull() {
+		var err error
+		constraints, err = version.NewConstraint(constraint.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("version"),
+				"Invalid version constraint",
+				"version must be a valid version constraint such as \">= 12\": "+err.Error(),
+			)
+			return nil, diags
+		}
+	}
+
+	templates, err := svc.ListTemplates(ctx)
+	if err != nil {
+		diags.AddError(
+			"Unable to refresh datasource",
+			"An unexpected error occurred while creating the datasource read request."+
+				"Please report this issue to the provider developers.\n\n"+
+				err.Error(),
+		)
+		return nil, diags
+	}
+
+	var matches []vmTemplateMatch
+	for _, t := range templates {
+		if !matchesFilter(family, t.Family()) {
+			continue
+		}
+		m := vmTemplateMatch{TemplateReadResponse: t}
+		m.version, _ = t.Version()
+		if constraints != nil && (m.version == nil || !constraints.Check(m.version)) {
+			continue
+		}
+		matches = append(matches, m)
+	}
+
+	slices.SortStableFunc(matches, func(a, b vmTemplateMatch) int {
+		if c := strings.Compare(a.Family(), b.Family()); c != 0 {
+			return c
+		}
+		// Templates without a version go last.
+		switch {
+		case a.version == nil && b.version == nil:
+			return 0
+		case a.version == nil:
+			return 1
+		case b.version == nil:
+			return -1
+		}
+		return b.version.Compare(a.version)
+	})
+	return matches, diags
+}
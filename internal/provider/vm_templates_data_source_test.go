@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+const testAccVMTemplatesDataSourceConfig = `
+data "oneprovider_vm_templates" "debian" {
+	family  = "Debian"
+	version = ">= 10"
+}
+`
+
+const testAccVMTemplatesInvalidVersionDataSourceConfig = `
+data "oneprovider_vm_templates" "debian" {
+	family  = "debian"
+	version = "latest"
+}
+`
+
+func TestAccVMTemplatesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVMTemplatesDataSourceConfig,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.oneprovider_vm_templates.debian",
+						tfjsonpath.New("templates"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectExact(map[string]knownvalue.Check{
+								"id":      knownvalue.StringExact("1055"),
+								"name":    knownvalue.StringExact("Debian 12 64bits"),
+								"family":  knownvalue.StringExact("debian"),
+								"version": knownvalue.StringExact("12"),
+								"size":    knownvalue.StringExact("5368709120"),
+							}),
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{"id": knownvalue.StringExact("979")}),
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{"id": knownvalue.StringExact("878")}),
+						}),
+					),
+				},
+			},
+			{
+				Config:      testAccVMTemplatesInvalidVersionDataSourceConfig,
+				ExpectError: regexp.MustCompile("Invalid version constraint"),
+			},
+		},
+	})
+}
@@ -4,31 +4,53 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
 	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	DefaultRateLimit      = 10
+	DefaultRateLimitBurst = 10
 )
 
-// APIError represents an error returned by the OneProvider API in the response body.
-type APIError struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+type Client struct {
+	apiKey      string
+	clientKey   string
+	endpoint    string
+	httpClient  *http.Client
+	retryPolicy RetryPolicy
+	limiter     *rate.Limiter
 }
 
-func (e *APIError) Error() string {
-	return fmt.Sprintf("api error %d: %s", e.Code, e.Message)
+// Option customizes a Client created with NewClient.
+type Option func(*Client)
+
+// WithRetryPolicy overrides the default retry policy.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.retryPolicy = p
+	}
 }
 
-type Client struct {
-	apiKey     string
-	clientKey  string
-	endpoint   string
-	httpClient *http.Client
+// WithRateLimit caps the client to requestsPerSecond with the given burst.
+// A requestsPerSecond of zero or less disables rate limiting.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *Client) {
+		if requestsPerSecond <= 0 {
+			c.limiter = rate.NewLimiter(rate.Inf, 0)
+			return
+		}
+		c.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), max(burst, 1))
+	}
 }
 
-func NewClient(endpoint, apiKey, clientKey string) (*Client, error) {
+func NewClient(endpoint, apiKey, clientKey string, opts ...Option) (*Client, error) {
 	endpoint = strings.TrimSpace(endpoint)
 	apiKey = strings.TrimSpace(apiKey)
 	clientKey = strings.TrimSpace(clientKey)
@@ -43,44 +65,130 @@ func NewClient(endpoint, apiKey, clientKey string) (*Client, error) {
 		return nil, fmt.Errorf("client: clientKey cannot be empty")
 	}
 
-	return &Client{
-		endpoint:   endpoint,
-		apiKey:     apiKey,
-		clientKey:  clientKey,
-		httpClient: &http.Client{Timeout: 30 * time.Second},
-	}, nil
+	c := &Client{
+		endpoint:    endpoint,
+		apiKey:      apiKey,
+		clientKey:   clientKey,
+		httpClient:  &http.Client{Timeout: 30 * time.Second},
+		retryPolicy: DefaultRetryPolicy(),
+		limiter:     rate.NewLimiter(DefaultRateLimit, DefaultRateLimitBurst),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
 }
 
 func (c *Client) MakeAPICall(ctx context.Context, method, endpoint string, body io.Reader, result any) error {
+	ctx = newLogContext(ctx)
+
+	// The body is buffered so that it can be replayed on every attempt.
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = io.ReadAll(body)
+		if err != nil {
+			return fmt.Errorf("client: failed to read request body: %w", err)
+		}
+	}
+
+	canRetry := c.retryPolicy.allows(method, endpoint)
+	for attempt := 1; ; attempt++ {
+		bodyBytes, retryAfter, err := c.do(ctx, method, endpoint, payload, attempt)
+		if err == nil {
+			return decodeResponse(bodyBytes, result)
+		}
+
+		var re *retryableError
+		if !errors.As(err, &re) {
+			return err
+		}
+		if !canRetry || attempt >= c.retryPolicy.MaxAttempts || ctx.Err() != nil {
+			return re.err
+		}
+
+		timer := time.NewTimer(c.retryPolicy.backoff(attempt, retryAfter))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return re.err
+		case <-timer.C:
+		}
+	}
+}
+
+// retryableError marks failures that a RetryPolicy may retry.
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+// do performs a single attempt and returns the raw response body.
+// The returned duration is the server's Retry-After hint, if any.
+func (c *Client) do(ctx context.Context, method, endpoint string, payload []byte, attempt int) ([]byte, time.Duration, error) {
+	// Every attempt, retries included, consumes a token shared by all callers of this client.
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, 0, fmt.Errorf("client: waiting for rate limiter: %w", err)
+	}
+
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
 	requestURL := fmt.Sprintf("%s%s", c.endpoint, endpoint)
 	req, err := http.NewRequestWithContext(ctx, method, requestURL, body)
 	if err != nil {
-		return err
+		return nil, 0, err
 	}
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Add("User-Agent", "OneApi/1.0")
 	req.Header.Add("Api-Key", c.apiKey)
 	req.Header.Add("Client-Key", c.clientKey)
 
+	logRequest(ctx, req, payload, attempt)
+	start := time.Now()
+
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return err
+		logTransportError(ctx, req, time.Since(start), err)
+		return nil, 0, &retryableError{err: err}
 	}
 	defer resp.Body.Close()
 
+	// Read the entire response body first
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		logTransportError(ctx, req, time.Since(start), err)
+		return nil, 0, &retryableError{err: fmt.Errorf("client: failed to read response body: %w", err)}
+	}
+	logResponse(ctx, req, resp.StatusCode, time.Since(start), bodyBytes)
+
 	// We cannot directly check the response status code
 	// because OneProvider API is always sending 200, hiding errors
 	// in the response body...
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("client: api request failed with status: %d", resp.StatusCode)
+		err = fmt.Errorf("client: api request failed with status: %d", resp.StatusCode)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			err = fmt.Errorf("%w: %w", err, ErrRateLimited)
+		}
+		if isRetryableStatus(resp.StatusCode) {
+			return nil, parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()), &retryableError{err: err}
+		}
+		return nil, 0, err
 	}
 
-	// Read the entire response body first
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("client: failed to read response body: %w", err)
-	}
+	return bodyBytes, 0, nil
+}
 
+func decodeResponse(bodyBytes []byte, result any) error {
 	// Check for API errors in the response body
 	var errorCheck struct {
 		Error *APIError `json:"error"`
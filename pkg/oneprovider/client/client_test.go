@@ -0,0 +1,246 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc, policy RetryPolicy) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(srv.URL, "api", "client", WithRetryPolicy(policy))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	return c
+}
+
+func fastRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+	}
+}
+
+func TestMakeAPICall_retriesGetOnServerError(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = io.WriteString(w, `{"result":"success","response":{"value":"ok"}}`)
+	}, fastRetryPolicy())
+
+	var result struct {
+		Response struct {
+			Value string `json:"value"`
+		} `json:"response"`
+	}
+	if err := c.MakeAPICall(context.Background(), http.MethodGet, "/vm/templates", nil, &result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected 3 calls, got %d", got)
+	}
+	if result.Response.Value != "ok" {
+		t.Errorf("expected decoded value ok, got %q", result.Response.Value)
+	}
+}
+
+func TestMakeAPICall_stopsAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}, fastRetryPolicy())
+
+	err := c.MakeAPICall(context.Background(), http.MethodGet, "/vm/locations", nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "429") {
+		t.Fatalf("expected status 429 error, got %v", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected 3 calls, got %d", got)
+	}
+}
+
+func TestMakeAPICall_doesNotRetryPostByDefault(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}, fastRetryPolicy())
+
+	err := c.MakeAPICall(context.Background(), http.MethodPost, "/vm/create", strings.NewReader("hostname=a"), nil)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected 1 call, got %d", got)
+	}
+}
+
+func TestMakeAPICall_retriesOptInPostWithSameBody(t *testing.T) {
+	var calls atomic.Int32
+	policy := fastRetryPolicy()
+	policy.RetryablePostEndpoints = []string{"/vm/hostname"}
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "hostname=a&vm_id=1" {
+			t.Errorf("unexpected body on attempt %d: %q", calls.Load()+1, body)
+		}
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = io.WriteString(w, `{"result":"success"}`)
+	}, policy)
+
+	err := c.MakeAPICall(context.Background(), http.MethodPost, "/vm/hostname", strings.NewReader("hostname=a&vm_id=1"), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected 2 calls, got %d", got)
+	}
+}
+
+func TestMakeAPICall_doesNotRetryAPIError(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = io.WriteString(w, `{"result":"error","error":{"code":810,"message":"VM not found"}}`)
+	}, fastRetryPolicy())
+
+	err := c.MakeAPICall(context.Background(), http.MethodGet, "/vm/info/1", nil, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != 810 {
+		t.Fatalf("expected api error 810, got %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected 1 call, got %d", got)
+	}
+}
+
+func TestMakeAPICall_honorsContextWhileWaiting(t *testing.T) {
+	policy := fastRetryPolicy()
+	policy.MinBackoff = time.Minute
+	policy.MaxBackoff = time.Minute
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}, policy)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := c.MakeAPICall(ctx, http.MethodGet, "/vm/sizes", nil, nil); err == nil {
+		t.Fatal("expected an error")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected context cancellation to interrupt the backoff, waited %s", elapsed)
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	cases := []struct {
+		retry      int
+		retryAfter time.Duration
+		want       time.Duration
+	}{
+		{retry: 1, want: time.Second},
+		{retry: 2, want: 2 * time.Second},
+		{retry: 3, want: 4 * time.Second},
+		{retry: 4, want: 5 * time.Second},
+		{retry: 1, retryAfter: 3 * time.Second, want: 3 * time.Second},
+		{retry: 1, retryAfter: time.Minute, want: 5 * time.Second},
+	}
+	for _, tc := range cases {
+		if got := p.backoff(tc.retry, tc.retryAfter); got != tc.want {
+			t.Errorf("backoff(%d, %s) = %s, want %s", tc.retry, tc.retryAfter, got, tc.want)
+		}
+	}
+
+	p.Jitter = true
+	for range 100 {
+		if got := p.backoff(3, 0); got < 2*time.Second || got > 4*time.Second {
+			t.Fatalf("jittered backoff out of range: %s", got)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := map[string]time.Duration{
+		"":                              0,
+		"7":                             7 * time.Second,
+		"-1":                            0,
+		"garbage":                       0,
+		"Mon, 01 Jan 2024 12:00:10 GMT": 10 * time.Second,
+		"Mon, 01 Jan 2024 11:00:00 GMT": 0,
+	}
+	for value, want := range cases {
+		if got := parseRetryAfter(value, now); got != want {
+			t.Errorf("parseRetryAfter(%q) = %s, want %s", value, got, want)
+		}
+	}
+}
+
+func TestMakeAPICall_rateLimited(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"result":"success"}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(srv.URL, "api", "client", WithRateLimit(20, 1))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	start := time.Now()
+	for range 5 {
+		if err := c.MakeAPICall(context.Background(), http.MethodGet, "/vm/sizes", nil, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	// The first request uses the burst, the four others wait 50ms each.
+	if elapsed := time.Since(start); elapsed < 190*time.Millisecond {
+		t.Errorf("expected requests to be throttled, took %s", elapsed)
+	}
+}
+
+func TestMakeAPICall_rateLimiterHonorsContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"result":"success"}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := NewClient(srv.URL, "api", "client", WithRateLimit(0.01, 1))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	if err := c.MakeAPICall(context.Background(), http.MethodGet, "/vm/sizes", nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := c.MakeAPICall(ctx, http.MethodGet, "/vm/sizes", nil, nil); err == nil {
+		t.Fatal("expected the rate limiter to give up on context deadline")
+	}
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Error codes answered by the OneProvider API in the response body. The API documentation
+// doesn't list them: CodeNotFound is the only one seen in real answers, the others are
+// assumptions shared with the fake API of the oneprovidertest package until real answers confirm them.
+const (
+	CodeInvalidCredentials = 101
+	CodeInvalidParameter   = 201
+	CodeInsufficientFunds  = 402
+	CodeNotFound           = 810
+	CodeLocationOutOfStock = 820
+	CodeInvalidState       = 830
+)
+
+// Sentinel errors for the API error conditions the provider knows how to handle.
+// An *APIError matches them with errors.Is, whatever the service wrapping it.
+var (
+	ErrNotFound           = errors.New("oneprovider: resource not found")
+	ErrInvalidCredentials = errors.New("oneprovider: invalid credentials")
+	ErrInsufficientFunds  = errors.New("oneprovider: insufficient funds")
+	// ErrRateLimited is reported for HTTP 429 answers, not for an error code.
+	ErrRateLimited        = errors.New("oneprovider: rate limited")
+	ErrInvalidParameter   = errors.New("oneprovider: invalid parameter")
+	ErrLocationOutOfStock = errors.New("oneprovider: location out of stock")
+	ErrInvalidState       = errors.New("oneprovider: operation not allowed in current state")
+)
+
+// errorCatalog maps OneProvider API error codes to their sentinel error.
+// Codes missing from this table still surface as an *APIError carrying
+// the original code and message.
+var errorCatalog = map[int]error{
+	CodeInvalidCredentials: ErrInvalidCredentials,
+	CodeInvalidParameter:   ErrInvalidParameter,
+	CodeInsufficientFunds:  ErrInsufficientFunds,
+	// Answered by /vm/info for an unknown VM, formerly hard-coded in vmInstanceResource.Read.
+	CodeNotFound:           ErrNotFound,
+	CodeLocationOutOfStock: ErrLocationOutOfStock,
+	CodeInvalidState:       ErrInvalidState,
+}
+
+// APIError represents an error returned by the OneProvider API in the response body.
+type APIError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("api error %d: %s", e.Code, e.Message)
+}
+
+// Is reports whether the error code is catalogued as target.
+func (e *APIError) Is(target error) bool {
+	sentinel, ok := errorCatalog[e.Code]
+	return ok && sentinel == target
+}
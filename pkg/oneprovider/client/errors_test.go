@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestAPIError_matchesCatalog(t *testing.T) {
+	err := fmt.Errorf("vm: get instance by ID failed: %w", &APIError{Code: CodeNotFound, Message: "VM not found"})
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected code 810 to match ErrNotFound")
+	}
+	if errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("expected code 810 not to match ErrInvalidCredentials")
+	}
+}
+
+func TestAPIError_unknownCodeRoundTrips(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"result":"error","error":{"code":99999,"message":"something odd"}}`)
+	}, fastRetryPolicy())
+
+	err := c.MakeAPICall(context.Background(), http.MethodGet, "/vm/info/1", nil, nil)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an *APIError, got %T", err)
+	}
+	if apiErr.Code != 99999 || apiErr.Message != "something odd" {
+		t.Errorf("unexpected api error: %+v", apiErr)
+	}
+	for _, sentinel := range []error{ErrNotFound, ErrInvalidCredentials, ErrInsufficientFunds, ErrRateLimited, ErrInvalidParameter, ErrLocationOutOfStock, ErrInvalidState} {
+		if errors.Is(err, sentinel) {
+			t.Errorf("unknown code should not match %v", sentinel)
+		}
+	}
+}
+
+func TestMakeAPICall_tooManyRequestsIsRateLimited(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}, RetryPolicy{MaxAttempts: 1})
+
+	err := c.MakeAPICall(context.Background(), http.MethodGet, "/vm/sizes", nil, nil)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited, got %v", err)
+	}
+}
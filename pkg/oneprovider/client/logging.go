@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// logSubsystem is the tflog subsystem used for API traffic. Its level follows
+// TF_LOG_PROVIDER_ONEPROVIDER and can be overridden with TF_LOG_PROVIDER_ONEPROVIDER_API.
+const logSubsystem = "api"
+
+const redacted = "***"
+
+// sensitiveFields lists headers and form fields whose value is never logged.
+var sensitiveFields = []string{"Api-Key", "Client-Key", "password", "key_value", "user_data"}
+
+var (
+	passwordJSONPattern = regexp.MustCompile(`("password"\s*:\s*")(?:[^"\\]|\\.)*(")`)
+	// sshKeyPattern matches the base64 blob of OpenSSH public keys, including
+	// the escaped slashes found in JSON payloads.
+	sshKeyPattern = regexp.MustCompile(`((?:ssh-(?:rsa|dss|ed25519)|ecdsa-sha2-nistp\d+|sk-(?:ssh-ed25519|ecdsa-sha2-nistp256)@openssh\.com)\s+)[A-Za-z0-9+/=\\]+`)
+)
+
+func newLogContext(ctx context.Context) context.Context {
+	return tflog.NewSubsystem(ctx, logSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_ONEPROVIDER", logSubsystem))
+}
+
+func logRequest(ctx context.Context, req *http.Request, payload []byte, attempt int) {
+	fields := map[string]any{
+		"http_method":  req.Method,
+		"http_path":    req.URL.Path,
+		"http_attempt": attempt,
+	}
+	tflog.SubsystemDebug(ctx, logSubsystem, "Sending OneProvider API request", fields)
+
+	fields["http_request_headers"] = redactHeaders(req.Header)
+	if form, err := url.ParseQuery(string(payload)); err == nil && len(form) > 0 {
+		fields["http_request_form"] = redactForm(form)
+	}
+	tflog.SubsystemTrace(ctx, logSubsystem, "OneProvider API request details", fields)
+}
+
+func logResponse(ctx context.Context, req *http.Request, status int, latency time.Duration, body []byte) {
+	fields := map[string]any{
+		"http_method":      req.Method,
+		"http_path":        req.URL.Path,
+		"http_status":      status,
+		"http_duration_ms": latency.Milliseconds(),
+	}
+	tflog.SubsystemDebug(ctx, logSubsystem, "Received OneProvider API response", fields)
+
+	fields["http_response_body"] = redactBody(body)
+	tflog.SubsystemTrace(ctx, logSubsystem, "OneProvider API response details", fields)
+}
+
+func logTransportError(ctx context.Context, req *http.Request, latency time.Duration, err error) {
+	tflog.SubsystemDebug(ctx, logSubsystem, "OneProvider API request failed", map[string]any{
+		"http_method":      req.Method,
+		"http_path":        req.URL.Path,
+		"http_duration_ms": latency.Milliseconds(),
+		"error":            err.Error(),
+	})
+}
+
+func isSensitiveField(name string) bool {
+	for _, f := range sensitiveFields {
+		if strings.EqualFold(f, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		if isSensitiveField(k) {
+			out[k] = redacted
+			continue
+		}
+		out[k] = strings.Join(v, ", ")
+	}
+	return out
+}
+
+func redactForm(form url.Values) map[string]string {
+	out := make(map[string]string, len(form))
+	for k, v := range form {
+		if isSensitiveField(k) {
+			out[k] = redacted
+			continue
+		}
+		out[k] = sshKeyPattern.ReplaceAllString(strings.Join(v, ", "), "${1}"+redacted)
+	}
+	return out
+}
+
+func redactBody(body []byte) string {
+	s := passwordJSONPattern.ReplaceAllString(string(body), "${1}"+redacted+"${2}")
+	return sshKeyPattern.ReplaceAllString(s, "${1}"+redacted)
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+const testPublicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIMbAn3/YgZhhmsQIiGjOPOhODxpKXUo+LF3rFBvOOnYl"
+
+func TestMakeAPICall_logsWithRedaction(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"result":"success","response":{"id":"1","password":"s3cr\"et","keys":[{"value":"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIMbAn3\/YgZhhmsQIiGjOPOhODxpKXUo+LF3rFBvOOnYl"}]}}`)
+	}, fastRetryPolicy())
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	form := "hostname=web&key_value=" + strings.ReplaceAll(testPublicKey, " ", "+") + "&password=hunter2&user_data=c2VjcmV0LXNjcmlwdA%3D%3D"
+	if err := c.MakeAPICall(ctx, http.MethodPost, "/vm/create", strings.NewReader(form), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("unable to decode logs: %v", err)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 log entries, got %d: %v", len(entries), entries)
+	}
+
+	logs := output.String()
+	for _, secret := range []string{"hunter2", "s3cr", "c2VjcmV0LXNjcmlwdA", "AAAAC3NzaC1lZDI1NTE5", `"api"`, `"client"`} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("logs leak %q: %s", secret, logs)
+		}
+	}
+
+	details := entries[1]
+	if details["http_method"] != http.MethodPost || details["http_path"] != "/vm/create" {
+		t.Errorf("unexpected request log entry: %v", details)
+	}
+	loggedForm, _ := details["http_request_form"].(map[string]any)
+	if loggedForm["hostname"] != "web" || loggedForm["password"] != redacted {
+		t.Errorf("unexpected logged form: %v", loggedForm)
+	}
+	if status := entries[2]["http_status"]; status != float64(http.StatusOK) {
+		t.Errorf("unexpected logged status: %v", status)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	got := redactBody([]byte(`{"password":"abc","value":"` + testPublicKey + ` user@host","name":"key"}`))
+	want := `{"password":"***","value":"ssh-ed25519 *** user@host","name":"key"}`
+	if got != want {
+		t.Errorf("redactBody() = %s, want %s", got, want)
+	}
+}
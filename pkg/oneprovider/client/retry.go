@@ -0,0 +1,98 @@
+package client
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultRetryMaxAttempts = 4
+	DefaultRetryMinBackoff  = 1 * time.Second
+	DefaultRetryMaxBackoff  = 30 * time.Second
+)
+
+// RetryPolicy controls how MakeAPICall retries failed requests.
+//
+// Only transport errors, 5xx and 429 responses are retried. GET requests are
+// always eligible; POST requests are only retried when their path is listed in
+// RetryablePostEndpoints, because most OneProvider POST endpoints are not idempotent.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// A value lower than 2 disables retries.
+	MaxAttempts int
+	// MinBackoff is the wait before the first retry. It doubles on each attempt.
+	MinBackoff time.Duration
+	// MaxBackoff caps both the exponential backoff and any Retry-After header.
+	MaxBackoff time.Duration
+	// Jitter randomizes each wait between half and the full computed backoff.
+	Jitter bool
+	// RetryablePostEndpoints lists POST paths (e.g. "/vm/hostname") that are safe to retry.
+	RetryablePostEndpoints []string
+}
+
+// DefaultRetryPolicy returns the policy used when none is given to NewClient.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: DefaultRetryMaxAttempts,
+		MinBackoff:  DefaultRetryMinBackoff,
+		MaxBackoff:  DefaultRetryMaxBackoff,
+		Jitter:      true,
+	}
+}
+
+func (p RetryPolicy) allows(method, endpoint string) bool {
+	if p.MaxAttempts < 2 {
+		return false
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	case http.MethodPost:
+		return slices.Contains(p.RetryablePostEndpoints, endpoint)
+	default:
+		return false
+	}
+}
+
+// backoff returns the wait before the given retry (1-based), preferring retryAfter when set.
+func (p RetryPolicy) backoff(retry int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return min(retryAfter, p.MaxBackoff)
+	}
+
+	wait := p.MinBackoff
+	for i := 1; i < retry && wait < p.MaxBackoff; i++ {
+		wait *= 2
+	}
+	wait = min(wait, p.MaxBackoff)
+
+	if p.Jitter && wait > 1 {
+		half := wait / 2
+		wait = half + rand.N(wait-half+1)
+	}
+	return wait
+}
+
+func isRetryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= 500
+}
+
+// parseRetryAfter understands both the delay-seconds and HTTP-date forms of the header.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0)
+	}
+	return 0
+}
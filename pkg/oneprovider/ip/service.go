@@ -0,0 +1,125 @@
+package ip
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/common"
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/client"
+)
+
+// ErrNotFound is returned by Get* methods when the requested IP address does not exist.
+// It also matches client.ErrNotFound, like the API error returned for unknown IP addresses.
+var ErrNotFound = fmt.Errorf("ip: %w", client.ErrNotFound)
+
+type Service struct {
+	client *client.Client
+}
+
+func NewService(c *client.Client) *Service {
+	return &Service{client: c}
+}
+
+// GetReverseDNS returns the PTR record of the IP address.
+// The error matches client.ErrNotFound when the IP address does not belong to the account.
+func (s *Service) GetReverseDNS(ctx context.Context, ipAddress string) (*ReverseDNSReadResponse, error) {
+	var resp ReverseDNSReadResponse
+
+	err := s.client.MakeAPICall(ctx, http.MethodGet, "/ip/rdns/"+url.PathEscape(ipAddress), nil, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("ip: get reverse dns of %s failed: %w", ipAddress, err)
+	}
+
+	return &resp, nil
+}
+
+// SetReverseDNS points the PTR record of the IP address to a hostname.
+func (s *Service) SetReverseDNS(ctx context.Context, req *ReverseDNSUpdateRequest) error {
+	err := s.client.MakeAPICall(ctx, http.MethodPost, "/ip/rdns", strings.NewReader(req.UrlValues().Encode()), nil)
+	if err != nil {
+		return fmt.Errorf("ip: set reverse dns failed: %w", err)
+	}
+	return nil
+}
+
+// ResetReverseDNS restores the PTR record OneProvider assigned to the IP address.
+func (s *Service) ResetReverseDNS(ctx context.Context, ipAddress string) error {
+	data := url.Values{"ip_address": {ipAddress}}
+
+	err := s.client.MakeAPICall(ctx, http.MethodPost, "/ip/rdns/reset", strings.NewReader(data.Encode()), nil)
+	if err != nil {
+		return fmt.Errorf("ip: reset reverse dns failed: %w", err)
+	}
+	return nil
+}
+
+// AllocateAddress orders an additional IP address in a location. It is billed until released.
+func (s *Service) AllocateAddress(ctx context.Context, req *AddressAllocateRequest) (*AddressAllocateResponse, error) {
+	var response AddressAllocateResponse
+
+	err := s.client.MakeAPICall(ctx, http.MethodPost, "/ip/allocate", strings.NewReader(req.UrlValues().Encode()), &response)
+	if err != nil {
+		return nil, fmt.Errorf("ip: allocate address failed: %w", err)
+	}
+
+	return &response, nil
+}
+
+// ListAddresses returns the additional IP addresses of the account.
+func (s *Service) ListAddresses(ctx context.Context) ([]AddressReadResponse, error) {
+	var response AddressesListResponse
+
+	err := s.client.MakeAPICall(ctx, http.MethodGet, "/ip/list", nil, &response)
+	if err != nil {
+		return nil, fmt.Errorf("ip: list addresses failed: %w", err)
+	}
+
+	return response.Response, nil
+}
+
+func (s *Service) GetAddressByID(ctx context.Context, id string) (*AddressReadResponse, error) {
+	addresses, err := s.ListAddresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	address, found := common.FindElement(addresses, func(a AddressReadResponse) bool { return a.Id == id })
+	if !found {
+		return nil, fmt.Errorf("ip: address not found for id %s: %w", id, ErrNotFound)
+	}
+	return &address, nil
+}
+
+// AssignAddress routes the IP address to a VM of the same location. An IP address
+// assigned to another VM is moved without being unassigned first.
+func (s *Service) AssignAddress(ctx context.Context, req *AddressAssignRequest) error {
+	err := s.client.MakeAPICall(ctx, http.MethodPost, "/ip/assign", strings.NewReader(req.UrlValues().Encode()), nil)
+	if err != nil {
+		return fmt.Errorf("ip: assign address failed: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) UnassignAddress(ctx context.Context, id string) error {
+	data := url.Values{"ip_id": {id}}
+
+	err := s.client.MakeAPICall(ctx, http.MethodPost, "/ip/unassign", strings.NewReader(data.Encode()), nil)
+	if err != nil {
+		return fmt.Errorf("ip: unassign address failed: %w", err)
+	}
+	return nil
+}
+
+// ReleaseAddress gives the IP address back to OneProvider.
+// The API refuses to release an assigned IP address.
+func (s *Service) ReleaseAddress(ctx context.Context, id string) error {
+	data := url.Values{"ip_id": {id}}
+
+	err := s.client.MakeAPICall(ctx, http.MethodPost, "/ip/release", strings.NewReader(data.Encode()), nil)
+	if err != nil {
+		return fmt.Errorf("ip: release address failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package ip
+
+import "net/url"
+
+type ReverseDNSReadResponse struct {
+	Response struct {
+		IpAddress string `json:"ip_address"`
+		Hostname  string `json:"hostname"`
+	} `json:"response"`
+}
+
+type ReverseDNSUpdateRequest struct {
+	IpAddress string `json:"ip_address"`
+	Hostname  string `json:"hostname"`
+}
+
+func (v *ReverseDNSUpdateRequest) UrlValues() url.Values {
+	return url.Values{
+		"ip_address": {v.IpAddress},
+		"hostname":   {v.Hostname},
+	}
+}
+
+// Types of additional IP addresses.
+const (
+	TypeIPv4 = "ipv4"
+	TypeIPv6 = "ipv6"
+)
+
+type AddressesListResponse struct {
+	Response []AddressReadResponse `json:"response"`
+}
+
+// AddressReadResponse is an additional IP address of the account.
+// VmId is empty while the IP address is not assigned.
+type AddressReadResponse struct {
+	Id         string `json:"id"`
+	IpAddress  string `json:"ip_address"`
+	Type       string `json:"type"`
+	LocationId string `json:"location_id"`
+	VmId       string `json:"vm_id"`
+}
+
+type AddressAllocateRequest struct {
+	LocationId string `json:"location_id"`
+	Type       string `json:"type"`
+}
+
+func (v *AddressAllocateRequest) UrlValues() url.Values {
+	return url.Values{
+		"location_id": {v.LocationId},
+		"type":        {v.Type},
+	}
+}
+
+type AddressAllocateResponse struct {
+	Response struct {
+		Message   string `json:"message"`
+		Id        string `json:"id"`
+		IpAddress string `json:"ip_address"`
+	} `json:"response"`
+}
+
+type AddressAssignRequest struct {
+	IpId string `json:"ip_id"`
+	VmId string `json:"vm_id"`
+}
+
+func (v *AddressAssignRequest) UrlValues() url.Values {
+	return url.Values{
+		"ip_id": {v.IpId},
+		"vm_id": {v.VmId},
+	}
+}
@@ -0,0 +1,225 @@
+package oneprovidertest
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/client"
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/server"
+)
+
+// dedicatedServerOS lists the operating systems a dedicated server can be reinstalled with, by ID.
+var dedicatedServerOS = map[string]string{
+	"21": "Debian 12 64bits",
+	"25": "Ubuntu 24.04 64bits",
+	"30": "AlmaLinux 9 64bits",
+}
+
+// DedicatedServer is the fake's view of a dedicated server.
+type DedicatedServer struct {
+	ID           string
+	Hostname     string
+	IPAddress    string
+	Location     string
+	Model        string
+	OSID         string
+	Password     string
+	SSHKeys      []string
+	State        string
+	Status       string
+	installPolls int
+	// installStalled keeps the server installing forever after a reinstall.
+	installStalled bool
+}
+
+// AddDedicatedServer stands for a dedicated server ordered out of band, running
+// Debian 12 in Paris. It returns the ID of the server.
+func (s *Server) AddDedicatedServer(hostname string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	srv := &DedicatedServer{
+		ID:        strconv.Itoa(s.nextID),
+		Hostname:  hostname,
+		IPAddress: fmt.Sprintf("203.0.113.%d", s.nextID%254+1),
+		Location:  "Paris",
+		Model:     "Xeon E3-1230v6",
+		OSID:      "21",
+		Password:  fmt.Sprintf("password-%d", s.nextID),
+		State:     server.StateOnline,
+		Status:    "running",
+	}
+	s.dedicatedServers[srv.ID] = srv
+	return srv.ID
+}
+
+// StallDedicatedServerInstall makes the next reinstalls of the dedicated server never complete.
+func (s *Server) StallDedicatedServerInstall(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if srv, ok := s.dedicatedServers[id]; ok {
+		srv.installStalled = true
+	}
+}
+
+// DedicatedServer returns a copy of the dedicated server with the given ID.
+func (s *Server) DedicatedServer(id string) (DedicatedServer, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	srv, ok := s.dedicatedServers[id]
+	if !ok {
+		return DedicatedServer{}, false
+	}
+	c := *srv
+	c.SSHKeys = slices.Clone(srv.SSHKeys)
+	return c, true
+}
+
+// handleDedicatedServerList lists the dedicated servers sorted by ID.
+func (s *Server) handleDedicatedServerList(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	servers := make([]DedicatedServer, 0, len(s.dedicatedServers))
+	for _, srv := range s.dedicatedServers {
+		servers = append(servers, *srv)
+	}
+	s.mu.Unlock()
+	slices.SortFunc(servers, func(a, b DedicatedServer) int { return strings.Compare(a.ID, b.ID) })
+
+	resp := server.ServersListResponse{Response: []server.ServerSummary{}}
+	for _, srv := range servers {
+		resp.Response = append(resp.Response, server.ServerSummary{
+			Id:        srv.ID,
+			Hostname:  srv.Hostname,
+			IpAddress: srv.IPAddress,
+			Location:  srv.Location,
+			Model:     srv.Model,
+			Os:        dedicatedServerOS[srv.OSID],
+			Status:    srv.Status,
+			State:     srv.State,
+		})
+	}
+	writeJSON(w, resp)
+}
+
+// handleDedicatedServerInfo reports server_install for InstallPolls calls after a reinstall.
+func (s *Server) handleDedicatedServerInfo(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	srv, ok := s.dedicatedServers[r.PathValue("id")]
+	if !ok {
+		writeError(w, client.CodeNotFound, "Server not found")
+		return
+	}
+
+	var resp server.ServerReadResponse
+	if srv.installPolls > 0 {
+		srv.installPolls--
+		resp.Response.ServerInstall = true
+	}
+	resp.Response.ServerInfo.IpAddress = srv.IPAddress
+	resp.Response.ServerInfo.Hostname = srv.Hostname
+	resp.Response.ServerInfo.Location = srv.Location
+	resp.Response.ServerInfo.Model = srv.Model
+	resp.Response.ServerInfo.Cpu = "4 cores / 8 threads"
+	resp.Response.ServerInfo.Ram = "32 GB"
+	resp.Response.ServerInfo.Storage = "2x 480 GB SSD"
+	resp.Response.ServerInfo.OsId = srv.OSID
+	resp.Response.ServerInfo.Os = dedicatedServerOS[srv.OSID]
+	resp.Response.ServerState.State = srv.State
+	resp.Response.ServerState.Status = srv.Status
+	writeJSON(w, resp)
+}
+
+func (s *Server) handleDedicatedServerHostname(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, client.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	srv, ok := s.dedicatedServers[r.PostForm.Get("server_id")]
+	if !ok {
+		writeError(w, client.CodeNotFound, "Server not found")
+		return
+	}
+	hostname := r.PostForm.Get("hostname")
+	if hostname == "" {
+		writeError(w, client.CodeInvalidParameter, "Missing hostname")
+		return
+	}
+	srv.Hostname = hostname
+	writeSuccess(w)
+}
+
+func (s *Server) handleDedicatedServerAction(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, client.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	srv, ok := s.dedicatedServers[r.PostForm.Get("server_id")]
+	if !ok {
+		writeError(w, client.CodeNotFound, "Server not found")
+		return
+	}
+	if r.PostForm.Get("action") != server.ServerActionReboot {
+		writeError(w, client.CodeInvalidParameter, "Invalid action")
+		return
+	}
+	srv.State, srv.Status = server.StateOnline, "running"
+	writeSuccess(w)
+}
+
+// handleDedicatedServerReinstall refuses to reinstall a server being installed.
+func (s *Server) handleDedicatedServerReinstall(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, client.CodeInvalidParameter, err.Error())
+		return
+	}
+	osID := r.PostForm.Get("os")
+	if _, ok := dedicatedServerOS[osID]; !ok {
+		writeError(w, client.CodeInvalidParameter, "Invalid os")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	srv, ok := s.dedicatedServers[r.PostForm.Get("server_id")]
+	if !ok {
+		writeError(w, client.CodeNotFound, "Server not found")
+		return
+	}
+	if srv.installPolls > 0 {
+		writeError(w, client.CodeInvalidState, "Server is being installed")
+		return
+	}
+	s.nextID++
+	srv.OSID = osID
+	if hostname := r.PostForm.Get("hostname"); hostname != "" {
+		srv.Hostname = hostname
+	}
+	srv.Password = fmt.Sprintf("password-%d", s.nextID)
+	srv.SSHKeys = formSSHKeys(r)
+	srv.State, srv.Status = server.StateOnline, "running"
+	srv.installPolls = s.InstallPolls
+	if srv.installStalled {
+		srv.installPolls = math.MaxInt
+	}
+
+	var resp server.ServerReinstallResponse
+	resp.Response.Message = "Server is being reinstalled"
+	resp.Response.Password = srv.Password
+	writeJSON(w, resp)
+}
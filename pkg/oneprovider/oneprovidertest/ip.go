@@ -0,0 +1,265 @@
+package oneprovidertest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/client"
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/ip"
+)
+
+// Address is the fake's view of an additional IP address.
+type Address struct {
+	ID         string
+	IPAddress  string
+	Type       string
+	LocationID string
+	VMID       string
+}
+
+// Addresses returns a copy of the additional IP addresses.
+func (s *Server) Addresses() []Address {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	addresses := make([]Address, 0, len(s.addresses))
+	for _, a := range s.addresses {
+		addresses = append(addresses, *a)
+	}
+	return addresses
+}
+
+// findAddress returns the additional IP address with the given ID. Callers must hold s.mu.
+func (s *Server) findAddress(id string) (*Address, bool) {
+	idx := slices.IndexFunc(s.addresses, func(a *Address) bool { return a.ID == id })
+	if idx == -1 {
+		return nil, false
+	}
+	return s.addresses[idx], true
+}
+
+// ReverseDNS returns the PTR record of an IP address of the account.
+func (s *Server) ReverseDNS(ipAddress string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.ownsIP(ipAddress) {
+		return "", false
+	}
+	return s.reverseDNSOf(ipAddress), true
+}
+
+// ownsIP reports whether the IP address belongs to the account. Callers must hold s.mu.
+func (s *Server) ownsIP(ipAddress string) bool {
+	for _, i := range s.instances {
+		if i.IPAddress == ipAddress {
+			return true
+		}
+	}
+	for _, srv := range s.dedicatedServers {
+		if srv.IPAddress == ipAddress {
+			return true
+		}
+	}
+	return slices.ContainsFunc(s.addresses, func(a *Address) bool { return a.IPAddress == ipAddress })
+}
+
+// reverseDNSOf returns the PTR record of the IP address. Callers must hold s.mu.
+func (s *Server) reverseDNSOf(ipAddress string) string {
+	if hostname, ok := s.reverseDNS[ipAddress]; ok {
+		return hostname
+	}
+	return defaultReverseDNS(ipAddress)
+}
+
+// defaultReverseDNS mimics the PTR record OneProvider assigns to its IP addresses.
+func defaultReverseDNS(ipAddress string) string {
+	return strings.NewReplacer(".", "-", ":", "-").Replace(ipAddress) + ".static.oneprovider.test"
+}
+
+func (s *Server) handleReverseDNSGet(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ipAddress := r.PathValue("ip")
+	if !s.ownsIP(ipAddress) {
+		writeError(w, client.CodeNotFound, "IP address not found")
+		return
+	}
+
+	var resp ip.ReverseDNSReadResponse
+	resp.Response.IpAddress = ipAddress
+	resp.Response.Hostname = s.reverseDNSOf(ipAddress)
+	writeJSON(w, resp)
+}
+
+func (s *Server) handleReverseDNSSet(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, client.CodeInvalidParameter, err.Error())
+		return
+	}
+	hostname := r.PostForm.Get("hostname")
+	if hostname == "" {
+		writeError(w, client.CodeInvalidParameter, "Missing hostname")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ipAddress := r.PostForm.Get("ip_address")
+	if !s.ownsIP(ipAddress) {
+		writeError(w, client.CodeNotFound, "IP address not found")
+		return
+	}
+	s.reverseDNS[ipAddress] = hostname
+	writeSuccess(w)
+}
+
+func (s *Server) handleReverseDNSReset(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, client.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ipAddress := r.PostForm.Get("ip_address")
+	if !s.ownsIP(ipAddress) {
+		writeError(w, client.CodeNotFound, "IP address not found")
+		return
+	}
+	delete(s.reverseDNS, ipAddress)
+	writeSuccess(w)
+}
+
+func (s *Server) handleAddressList(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := ip.AddressesListResponse{Response: []ip.AddressReadResponse{}}
+	for _, a := range s.addresses {
+		resp.Response = append(resp.Response, ip.AddressReadResponse{
+			Id:         a.ID,
+			IpAddress:  a.IPAddress,
+			Type:       a.Type,
+			LocationId: a.LocationID,
+			VmId:       a.VMID,
+		})
+	}
+	writeJSON(w, resp)
+}
+
+// handleAddressAllocate only allocates the IP versions available in the location.
+func (s *Server) handleAddressAllocate(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, client.CodeInvalidParameter, err.Error())
+		return
+	}
+	location, ok := s.findLocation(r.PostForm.Get("location_id"))
+	if !ok {
+		writeError(w, client.CodeInvalidParameter, "Invalid location_id")
+		return
+	}
+	addressType := r.PostForm.Get("type")
+	switch {
+	case addressType == ip.TypeIPv4 && location.SupportsIPv4():
+	case addressType == ip.TypeIPv6 && location.SupportsIPv6():
+	default:
+		writeError(w, client.CodeInvalidParameter, "Invalid type for this location")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	address := &Address{
+		ID:         strconv.Itoa(s.nextID),
+		IPAddress:  fmt.Sprintf("198.51.100.%d", s.nextID%254+1),
+		Type:       addressType,
+		LocationID: location.Id,
+	}
+	if addressType == ip.TypeIPv6 {
+		address.IPAddress = fmt.Sprintf("2001:db8:f::%x", s.nextID)
+	}
+	s.addresses = append(s.addresses, address)
+
+	var resp ip.AddressAllocateResponse
+	resp.Response.Message = "IP address allocated"
+	resp.Response.Id = address.ID
+	resp.Response.IpAddress = address.IPAddress
+	writeJSON(w, resp)
+}
+
+// handleAddressAssign moves the IP address to a VM of its location.
+func (s *Server) handleAddressAssign(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, client.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	address, ok := s.findAddress(r.PostForm.Get("ip_id"))
+	if !ok {
+		writeError(w, client.CodeNotFound, "IP address not found")
+		return
+	}
+	instance, ok := s.instances[r.PostForm.Get("vm_id")]
+	if !ok {
+		writeError(w, client.CodeNotFound, "VM not found")
+		return
+	}
+	if instance.LocationID != address.LocationID {
+		writeError(w, client.CodeInvalidParameter, "VM is not in the location of the IP address")
+		return
+	}
+	address.VMID = instance.ID
+	writeSuccess(w)
+}
+
+func (s *Server) handleAddressUnassign(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, client.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	address, ok := s.findAddress(r.PostForm.Get("ip_id"))
+	if !ok {
+		writeError(w, client.CodeNotFound, "IP address not found")
+		return
+	}
+	address.VMID = ""
+	writeSuccess(w)
+}
+
+// handleAddressRelease refuses to release an assigned IP address.
+func (s *Server) handleAddressRelease(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, client.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	address, ok := s.findAddress(r.PostForm.Get("ip_id"))
+	if !ok {
+		writeError(w, client.CodeNotFound, "IP address not found")
+		return
+	}
+	if address.VMID != "" {
+		writeError(w, client.CodeInvalidState, "IP address is assigned")
+		return
+	}
+	delete(s.reverseDNS, address.IPAddress)
+	s.addresses = slices.DeleteFunc(s.addresses, func(a *Address) bool { return a.ID == address.ID })
+	writeSuccess(w)
+}
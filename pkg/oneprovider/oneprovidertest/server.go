@@ -0,0 +1,722 @@
+// Package oneprovidertest provides an in-process fake of the OneProvider API.
+//
+// The fake is stateful: VMs and SSH keys created through it can be read back,
+// updated and destroyed. Catalog endpoints are served from the fixtures
+// embedded by the api package. Like the real API, every answer is sent with a
+// 200 status code and errors are reported in the response body.
+package oneprovidertest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/MadJlzz/terraform-provider-oneprovider/api"
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/client"
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/ssh"
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/vm"
+)
+
+const (
+	// APIKey and ClientKey are the only credentials accepted by the fake.
+	APIKey    = "oneprovidertest-api-key"
+	ClientKey = "oneprovidertest-client-key"
+
+	// DefaultInstallPolls is the number of /vm/info calls during which a new
+	// VM reports server_install before becoming ready.
+	DefaultInstallPolls = 1
+
+	// DefaultSnapshotPolls is the number of /vm/snapshot/list calls during which
+	// a new snapshot reports creating before becoming available.
+	DefaultSnapshotPolls = 1
+)
+
+// Instance is the fake's view of a VM.
+type Instance struct {
+	ID           string
+	LocationID   string
+	SizeID       string
+	TemplateID   int
+	Hostname     string
+	IPAddress    string
+	IPv6Address  string
+	IPv6Prefix   string
+	Password     string
+	SSHKeys      []string
+	UserData     []byte
+	State        string
+	Status       string
+	installPolls int
+}
+
+// Server is a stateful fake of the OneProvider API backed by an httptest.Server.
+type Server struct {
+	*httptest.Server
+
+	// InstallPolls is copied to every VM created after it is set.
+	InstallPolls int
+	// SnapshotPolls is copied to every snapshot created after it is set.
+	SnapshotPolls int
+
+	mu        sync.Mutex
+	nextID    int
+	instances map[string]*Instance
+	snapshots []*Snapshot
+	// dedicatedServers are never created through the API, see AddDedicatedServer.
+	dedicatedServers map[string]*DedicatedServer
+	addresses        []*Address
+	// reverseDNS holds the PTR records changed from their default, by IP address.
+	reverseDNS map[string]string
+	sshKeys    []ssh.SshKeyReadResponse
+
+	templates []byte
+	locations []byte
+	sizes     []byte
+}
+
+// NewServer starts a fake API. Callers must Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		InstallPolls:     DefaultInstallPolls,
+		SnapshotPolls:    DefaultSnapshotPolls,
+		nextID:           1000,
+		instances:        map[string]*Instance{},
+		dedicatedServers: map[string]*DedicatedServer{},
+		reverseDNS:       map[string]string{},
+		templates:        mustReadFixture("vm_templates.json"),
+		locations:        mustReadFixture("vm_locations.json"),
+		sizes:            mustReadFixture("vm_sizes.json"),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /vm/templates", s.serveFixture(s.templates))
+	mux.HandleFunc("GET /vm/templates/", s.serveFixture(s.templates))
+	mux.HandleFunc("GET /vm/locations", s.serveFixture(s.locations))
+	mux.HandleFunc("GET /vm/sizes", s.serveFixture(s.sizes))
+	mux.HandleFunc("GET /vm/listing", s.handleInstanceList)
+	mux.HandleFunc("GET /vm/info/{id}", s.handleInstanceInfo)
+	mux.HandleFunc("POST /vm/create", s.handleInstanceCreate)
+	mux.HandleFunc("POST /vm/hostname", s.handleInstanceHostname)
+	mux.HandleFunc("POST /vm/destroy", s.handleInstanceDestroy)
+	mux.HandleFunc("POST /vm/action", s.handleInstanceAction)
+	mux.HandleFunc("POST /vm/reinstall", s.handleInstanceReinstall)
+	mux.HandleFunc("POST /vm/resize", s.handleInstanceResize)
+	mux.HandleFunc("POST /vm/password/reset", s.handleInstancePasswordReset)
+	mux.HandleFunc("POST /vm/sshkey/attach", s.handleInstanceSSHKeyAttach)
+	mux.HandleFunc("POST /vm/sshkey/detach", s.handleInstanceSSHKeyDetach)
+	mux.HandleFunc("GET /vm/sshkeys/list", s.handleSSHKeyList)
+	mux.HandleFunc("POST /vm/sshkey/new", s.handleSSHKeyCreate)
+	mux.HandleFunc("POST /vm/sshkey/edit", s.handleSSHKeyEdit)
+	mux.HandleFunc("POST /vm/sshkey/delete", s.handleSSHKeyDelete)
+	mux.HandleFunc("GET /vm/snapshot/list", s.handleSnapshotList)
+	mux.HandleFunc("POST /vm/snapshot/create", s.handleSnapshotCreate)
+	mux.HandleFunc("POST /vm/snapshot/delete", s.handleSnapshotDelete)
+	mux.HandleFunc("POST /vm/snapshot/restore", s.handleSnapshotRestore)
+	mux.HandleFunc("GET /server/list", s.handleDedicatedServerList)
+	mux.HandleFunc("GET /server/info/{id}", s.handleDedicatedServerInfo)
+	mux.HandleFunc("POST /server/hostname", s.handleDedicatedServerHostname)
+	mux.HandleFunc("POST /server/action", s.handleDedicatedServerAction)
+	mux.HandleFunc("POST /server/reinstall", s.handleDedicatedServerReinstall)
+	mux.HandleFunc("GET /ip/list", s.handleAddressList)
+	mux.HandleFunc("POST /ip/allocate", s.handleAddressAllocate)
+	mux.HandleFunc("POST /ip/assign", s.handleAddressAssign)
+	mux.HandleFunc("POST /ip/unassign", s.handleAddressUnassign)
+	mux.HandleFunc("POST /ip/release", s.handleAddressRelease)
+	mux.HandleFunc("GET /ip/rdns/{ip}", s.handleReverseDNSGet)
+	mux.HandleFunc("POST /ip/rdns", s.handleReverseDNSSet)
+	mux.HandleFunc("POST /ip/rdns/reset", s.handleReverseDNSReset)
+
+	s.Server = httptest.NewServer(s.authenticate(mux))
+	return s
+}
+
+// Instance returns a copy of the VM with the given ID.
+func (s *Server) Instance(id string) (Instance, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i, ok := s.instances[id]
+	if !ok {
+		return Instance{}, false
+	}
+	c := *i
+	c.SSHKeys = slices.Clone(i.SSHKeys)
+	c.UserData = slices.Clone(i.UserData)
+	return c, true
+}
+
+// SSHKeys returns a copy of the stored SSH keys.
+func (s *Server) SSHKeys() []ssh.SshKeyReadResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.sshKeys)
+}
+
+func mustReadFixture(name string) []byte {
+	b, err := fs.ReadFile(api.Fixtures, name)
+	if err != nil {
+		panic(fmt.Sprintf("oneprovidertest: reading fixture %s: %v", name, err))
+	}
+	return b
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Api-Key") != APIKey || r.Header.Get("Client-Key") != ClientKey {
+			writeError(w, client.CodeInvalidCredentials, "Invalid API credentials")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) serveFixture(body []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}
+}
+
+func (s *Server) handleInstanceCreate(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, client.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	location, ok := s.findLocation(r.PostForm.Get("location_id"))
+	if !ok {
+		writeError(w, client.CodeInvalidParameter, "Invalid location_id")
+		return
+	}
+	sizeID, err := strconv.Atoi(r.PostForm.Get("instance_size"))
+	if err != nil || !slices.Contains(location.AvailableSizes, sizeID) {
+		writeError(w, client.CodeInvalidParameter, "Invalid instance_size for this location")
+		return
+	}
+	var templateID int
+	if snapshotID := r.PostForm.Get("snapshot"); snapshotID != "" {
+		// The VM is cloned from the snapshot, which must fit on the disk.
+		s.mu.Lock()
+		snapshot, ok := s.findSnapshot(snapshotID)
+		s.mu.Unlock()
+		size, _ := s.findSize(strconv.Itoa(sizeID))
+		disk, _ := strconv.Atoi(size.Disk)
+		if !ok || snapshot.Status != vm.SnapshotStatusAvailable || snapshot.SizeGB > disk {
+			writeError(w, client.CodeInvalidParameter, "Invalid snapshot")
+			return
+		}
+		templateID = snapshot.TemplateID
+	} else {
+		templateID, err = strconv.Atoi(r.PostForm.Get("template"))
+		if _, found := s.findTemplate(templateID); err != nil || !found {
+			writeError(w, client.CodeInvalidParameter, "Invalid template")
+			return
+		}
+	}
+	hostname := r.PostForm.Get("hostname")
+	if hostname == "" {
+		writeError(w, client.CodeInvalidParameter, "Missing hostname")
+		return
+	}
+	userData, err := base64.StdEncoding.DecodeString(r.PostForm.Get("user_data"))
+	if err != nil || len(userData) > vm.MaxUserDataSize {
+		writeError(w, client.CodeInvalidParameter, "Invalid user_data")
+		return
+	}
+	enableIPv6 := isTrue(r.PostForm.Get("ipv6"))
+	if enableIPv6 && !location.SupportsIPv6() {
+		writeError(w, client.CodeInvalidParameter, "IPv6 is not available in this location")
+		return
+	}
+
+	s.mu.Lock()
+	s.nextID++
+	instance := &Instance{
+		ID:           strconv.Itoa(s.nextID),
+		LocationID:   location.Id,
+		SizeID:       strconv.Itoa(sizeID),
+		TemplateID:   templateID,
+		Hostname:     hostname,
+		IPAddress:    fmt.Sprintf("192.0.2.%d", s.nextID%254+1),
+		Password:     fmt.Sprintf("password-%d", s.nextID),
+		SSHKeys:      formSSHKeys(r),
+		UserData:     userData,
+		State:        vm.InstanceStateOnline,
+		Status:       "running",
+		installPolls: s.InstallPolls,
+	}
+	if enableIPv6 {
+		instance.IPv6Address = fmt.Sprintf("2001:db8:%x::1", s.nextID)
+		instance.IPv6Prefix = fmt.Sprintf("2001:db8:%x::/64", s.nextID)
+	}
+	s.instances[instance.ID] = instance
+	s.mu.Unlock()
+
+	var resp vm.InstanceCreateResponse
+	resp.Response.Message = "VM is being created"
+	resp.Response.Id = instance.ID
+	resp.Response.IpAddress = instance.IPAddress
+	resp.Response.Hostname = instance.Hostname
+	resp.Response.Password = instance.Password
+	writeJSON(w, resp)
+}
+
+// handleInstanceList lists the VMs sorted by ID. VMs being installed are reported
+// without details, like /vm/info does.
+func (s *Server) handleInstanceList(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	instances := make([]Instance, 0, len(s.instances))
+	for _, i := range s.instances {
+		instances = append(instances, *i)
+	}
+	s.mu.Unlock()
+	slices.SortFunc(instances, func(a, b Instance) int { return strings.Compare(a.ID, b.ID) })
+
+	var resp vm.InstancesListResponse
+	resp.Response = []vm.InstanceSummary{}
+	for _, i := range instances {
+		summary := vm.InstanceSummary{Id: i.ID, State: vm.InstanceStateOffline, Status: "installing"}
+		if i.installPolls == 0 {
+			location, _ := s.findLocation(i.LocationID)
+			size, _ := s.findSize(i.SizeID)
+			template, _ := s.findTemplate(i.TemplateID)
+
+			summary.Hostname = i.Hostname
+			summary.IpAddress = i.IPAddress
+			summary.City = location.City
+			summary.Plan = size.Name
+			summary.Template = template.Name
+			summary.State = i.State
+			summary.Status = i.Status
+		}
+		resp.Response = append(resp.Response, summary)
+	}
+	writeJSON(w, resp)
+}
+
+func (s *Server) handleInstanceInfo(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	instance, ok := s.instances[r.PathValue("id")]
+	if !ok {
+		s.mu.Unlock()
+		writeError(w, client.CodeNotFound, "VM not found")
+		return
+	}
+
+	var resp vm.InstanceReadResponse
+	if instance.installPolls > 0 {
+		// Like the real API, a VM being installed is reported offline with empty details.
+		instance.installPolls--
+		resp.Response.ServerInstall = true
+		resp.Response.ServerState.State = vm.InstanceStateOffline
+		resp.Response.ServerState.Status = "installing"
+		s.mu.Unlock()
+		writeJSON(w, resp)
+		return
+	}
+	i := *instance
+	s.mu.Unlock()
+
+	location, _ := s.findLocation(i.LocationID)
+	size, _ := s.findSize(i.SizeID)
+	template, _ := s.findTemplate(i.TemplateID)
+
+	resp.Response.ServerInfo.IpAddress = i.IPAddress
+	resp.Response.ServerInfo.IPv6Address = i.IPv6Address
+	resp.Response.ServerInfo.IPv6Prefix = i.IPv6Prefix
+	resp.Response.ServerInfo.Hostname = i.Hostname
+	resp.Response.ServerInfo.City = location.City
+	resp.Response.ServerInfo.Plan = size.Name
+	resp.Response.ServerInfo.Template = template.Name
+	resp.Response.ServerInfo.SshKeys = append([]string{}, i.SSHKeys...)
+	resp.Response.ServerState.State = i.State
+	resp.Response.ServerState.Status = i.Status
+	writeJSON(w, resp)
+}
+
+func (s *Server) handleInstanceHostname(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, client.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instance, ok := s.instances[r.PostForm.Get("vm_id")]
+	if !ok {
+		writeError(w, client.CodeNotFound, "VM not found")
+		return
+	}
+	hostname := r.PostForm.Get("hostname")
+	if hostname == "" {
+		writeError(w, client.CodeInvalidParameter, "Missing hostname")
+		return
+	}
+	instance.Hostname = hostname
+	writeSuccess(w)
+}
+
+func (s *Server) handleInstanceAction(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, client.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instance, ok := s.instances[r.PostForm.Get("vm_id")]
+	if !ok {
+		writeError(w, client.CodeNotFound, "VM not found")
+		return
+	}
+	switch r.PostForm.Get("action") {
+	case vm.InstanceActionStart, vm.InstanceActionReboot:
+		instance.State, instance.Status = vm.InstanceStateOnline, "running"
+	case vm.InstanceActionStop:
+		instance.State, instance.Status = vm.InstanceStateOffline, "stopped"
+	default:
+		writeError(w, client.CodeInvalidParameter, "Invalid action")
+		return
+	}
+	writeSuccess(w)
+}
+
+func (s *Server) handleInstanceSSHKeyAttach(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, client.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instance, ok := s.instances[r.PostForm.Get("vm_id")]
+	if !ok {
+		writeError(w, client.CodeNotFound, "VM not found")
+		return
+	}
+	key := r.PostForm.Get("ssh_key")
+	if key == "" {
+		writeError(w, client.CodeInvalidParameter, "Missing ssh_key")
+		return
+	}
+	if !slices.Contains(instance.SSHKeys, key) {
+		instance.SSHKeys = append(instance.SSHKeys, key)
+	}
+	writeSuccess(w)
+}
+
+func (s *Server) handleInstanceSSHKeyDetach(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, client.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instance, ok := s.instances[r.PostForm.Get("vm_id")]
+	if !ok {
+		writeError(w, client.CodeNotFound, "VM not found")
+		return
+	}
+	key := r.PostForm.Get("ssh_key")
+	if !slices.Contains(instance.SSHKeys, key) {
+		writeError(w, client.CodeNotFound, "SSH key not attached")
+		return
+	}
+	instance.SSHKeys = slices.DeleteFunc(instance.SSHKeys, func(k string) bool { return k == key })
+	writeSuccess(w)
+}
+
+func (s *Server) handleInstanceReinstall(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, client.CodeInvalidParameter, err.Error())
+		return
+	}
+	templateID, err := strconv.Atoi(r.PostForm.Get("template"))
+	if _, found := s.findTemplate(templateID); err != nil || !found {
+		writeError(w, client.CodeInvalidParameter, "Invalid template")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instance, ok := s.instances[r.PostForm.Get("vm_id")]
+	if !ok {
+		writeError(w, client.CodeNotFound, "VM not found")
+		return
+	}
+	s.nextID++
+	instance.TemplateID = templateID
+	instance.Password = fmt.Sprintf("password-%d", s.nextID)
+	instance.SSHKeys = formSSHKeys(r)
+	instance.State, instance.Status = vm.InstanceStateOnline, "running"
+	instance.installPolls = s.InstallPolls
+
+	var resp vm.InstanceReinstallResponse
+	resp.Response.Message = "VM is being reinstalled"
+	resp.Response.Password = instance.Password
+	writeJSON(w, resp)
+}
+
+// handleInstancePasswordReset refuses to reset the password of a VM being installed.
+func (s *Server) handleInstancePasswordReset(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, client.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instance, ok := s.instances[r.PostForm.Get("vm_id")]
+	if !ok {
+		writeError(w, client.CodeNotFound, "VM not found")
+		return
+	}
+	if instance.installPolls > 0 {
+		writeError(w, client.CodeInvalidState, "VM is being installed")
+		return
+	}
+	s.nextID++
+	instance.Password = fmt.Sprintf("password-%d", s.nextID)
+
+	var resp vm.InstancePasswordResetResponse
+	resp.Response.Message = "Password has been reset"
+	resp.Response.Password = instance.Password
+	writeJSON(w, resp)
+}
+
+// handleInstanceResize only resizes stopped VMs and refuses to shrink disks.
+func (s *Server) handleInstanceResize(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, client.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instance, ok := s.instances[r.PostForm.Get("vm_id")]
+	if !ok {
+		writeError(w, client.CodeNotFound, "VM not found")
+		return
+	}
+	if instance.State != vm.InstanceStateOffline {
+		writeError(w, client.CodeInvalidState, "VM must be stopped before being resized")
+		return
+	}
+
+	location, _ := s.findLocation(instance.LocationID)
+	sizeID, err := strconv.Atoi(r.PostForm.Get("instance_size"))
+	if err != nil || !slices.Contains(location.AvailableSizes, sizeID) {
+		writeError(w, client.CodeInvalidParameter, "Invalid instance_size for this location")
+		return
+	}
+	current, _ := s.findSize(instance.SizeID)
+	target, _ := s.findSize(strconv.Itoa(sizeID))
+	currentDisk, _ := strconv.Atoi(current.Disk)
+	targetDisk, _ := strconv.Atoi(target.Disk)
+	if targetDisk < currentDisk {
+		writeError(w, client.CodeInvalidParameter, "Disk cannot be shrunk")
+		return
+	}
+
+	instance.SizeID = target.Id
+	writeSuccess(w)
+}
+
+func (s *Server) handleInstanceDestroy(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, client.CodeInvalidParameter, err.Error())
+		return
+	}
+	if !isTrue(r.PostForm.Get("confirm_close")) {
+		writeError(w, client.CodeInvalidParameter, "confirm_close must be true")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PostForm.Get("vm_id")
+	instance, ok := s.instances[id]
+	if !ok {
+		writeError(w, client.CodeNotFound, "VM not found")
+		return
+	}
+	delete(s.reverseDNS, instance.IPAddress)
+	delete(s.instances, id)
+	// Additional IP addresses stay allocated to the account.
+	for _, a := range s.addresses {
+		if a.VMID == id {
+			a.VMID = ""
+		}
+	}
+	writeSuccess(w)
+}
+
+func (s *Server) handleSSHKeyList(w http.ResponseWriter, r *http.Request) {
+	var resp ssh.SshKeyListResponse
+	resp.Response.SshKeys = s.SSHKeys()
+	if resp.Response.SshKeys == nil {
+		resp.Response.SshKeys = []ssh.SshKeyReadResponse{}
+	}
+	writeJSON(w, resp)
+}
+
+func (s *Server) handleSSHKeyCreate(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, client.CodeInvalidParameter, err.Error())
+		return
+	}
+	name, value := r.PostForm.Get("key_name"), r.PostForm.Get("key_value")
+	if name == "" || value == "" {
+		writeError(w, client.CodeInvalidParameter, "key_name and key_value are required")
+		return
+	}
+
+	s.mu.Lock()
+	s.nextID++
+	key := ssh.SshKeyReadResponse{
+		Uuid:  fmt.Sprintf("00000000-0000-4000-8000-%012d", s.nextID),
+		Name:  name,
+		Value: value,
+	}
+	s.sshKeys = append(s.sshKeys, key)
+	s.mu.Unlock()
+
+	var resp ssh.SshKeyCreateResponse
+	resp.Response.Key.Uuid = key.Uuid
+	resp.Response.Key.Name = key.Name
+	resp.Response.Key.Value = key.Value
+	writeJSON(w, resp)
+}
+
+func (s *Server) handleSSHKeyEdit(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, client.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx := slices.IndexFunc(s.sshKeys, func(k ssh.SshKeyReadResponse) bool {
+		return k.Uuid == r.PostForm.Get("ssh_key")
+	})
+	if idx == -1 {
+		writeError(w, client.CodeNotFound, "SSH key not found")
+		return
+	}
+	if name := r.PostForm.Get("key_name"); name != "" {
+		s.sshKeys[idx].Name = name
+	}
+	if value := r.PostForm.Get("key_value"); value != "" {
+		s.sshKeys[idx].Value = value
+	}
+	writeSuccess(w)
+}
+
+func (s *Server) handleSSHKeyDelete(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, client.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	uuid := r.PostForm.Get("ssh_key")
+	idx := slices.IndexFunc(s.sshKeys, func(k ssh.SshKeyReadResponse) bool { return k.Uuid == uuid })
+	if idx == -1 {
+		writeError(w, client.CodeNotFound, "SSH key not found")
+		return
+	}
+	s.sshKeys = slices.Delete(s.sshKeys, idx, idx+1)
+	writeSuccess(w)
+}
+
+func (s *Server) findLocation(id string) (vm.LocationReadResponse, bool) {
+	var resp vm.LocationsListResponse
+	if err := json.Unmarshal(s.locations, &resp); err != nil {
+		return vm.LocationReadResponse{}, false
+	}
+	for _, locations := range resp.Response {
+		for _, l := range locations {
+			if l.Id == id {
+				return l, true
+			}
+		}
+	}
+	return vm.LocationReadResponse{}, false
+}
+
+func (s *Server) findSize(id string) (vm.SizeReadResponse, bool) {
+	var resp vm.SizesListResponse
+	if err := json.Unmarshal(s.sizes, &resp); err != nil {
+		return vm.SizeReadResponse{}, false
+	}
+	idx := slices.IndexFunc(resp.Response, func(sz vm.SizeReadResponse) bool { return sz.Id == id })
+	if idx == -1 {
+		return vm.SizeReadResponse{}, false
+	}
+	return resp.Response[idx], true
+}
+
+func (s *Server) findTemplate(id int) (vm.TemplateReadResponse, bool) {
+	var resp vm.TemplatesListResponse
+	if err := json.Unmarshal(s.templates, &resp); err != nil {
+		return vm.TemplateReadResponse{}, false
+	}
+	idx := slices.IndexFunc(resp.Templates, func(t vm.TemplateReadResponse) bool { return t.Id == id })
+	if idx == -1 {
+		return vm.TemplateReadResponse{}, false
+	}
+	return resp.Templates[idx], true
+}
+
+// formSSHKeys collects the ssh_keys[i] form values.
+func formSSHKeys(r *http.Request) []string {
+	var sshKeys []string
+	for idx := 0; r.PostForm.Has(fmt.Sprintf("ssh_keys[%d]", idx)); idx++ {
+		sshKeys = append(sshKeys, r.PostForm.Get(fmt.Sprintf("ssh_keys[%d]", idx)))
+	}
+	return sshKeys
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeSuccess(w http.ResponseWriter) {
+	writeJSON(w, map[string]any{"result": "success", "response": map[string]any{}})
+}
+
+// writeError mimics the API: a 200 status with the error in the body.
+func writeError(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, map[string]any{
+		"result": "error",
+		"error": map[string]any{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
+
+// isTrue parses the boolean form values sent by the client.
+func isTrue(v string) bool {
+	b, _ := strconv.ParseBool(strings.TrimSpace(v))
+	return b
+}
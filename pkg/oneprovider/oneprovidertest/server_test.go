@@ -0,0 +1,532 @@
+package oneprovidertest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider"
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/client"
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/ip"
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/oneprovidertest"
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/server"
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/ssh"
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/vm"
+)
+
+func newService(t *testing.T) (*oneprovidertest.Server, *oneprovider.Service) {
+	t.Helper()
+	srv := oneprovidertest.NewServer()
+	t.Cleanup(srv.Close)
+
+	svc, err := oneprovider.NewService(srv.URL, oneprovidertest.APIKey, oneprovidertest.ClientKey)
+	if err != nil {
+		t.Fatalf("unexpected error creating service: %v", err)
+	}
+	return srv, svc
+}
+
+func TestServer_rejectsInvalidCredentials(t *testing.T) {
+	srv := oneprovidertest.NewServer()
+	t.Cleanup(srv.Close)
+
+	svc, err := oneprovider.NewService(srv.URL, "wrong", "wrong")
+	if err != nil {
+		t.Fatalf("unexpected error creating service: %v", err)
+	}
+	_, err = svc.VM.GetSizeByName(context.Background(), "02d30c1")
+	if !errors.Is(err, client.ErrInvalidCredentials) {
+		t.Errorf("expected an invalid credentials error, got %v", err)
+	}
+}
+
+func TestServer_catalog(t *testing.T) {
+	_, svc := newService(t)
+	ctx := context.Background()
+
+	l, err := svc.VM.GetLocationByCity(ctx, "Brussels")
+	if err != nil || l.Id != "33" {
+		t.Errorf("unexpected location: %+v, %v", l, err)
+	}
+	s, err := svc.VM.GetSizeByName(ctx, "01d20c1-2")
+	if err != nil || s.Id != "71" {
+		t.Errorf("unexpected size: %+v, %v", s, err)
+	}
+	locations, err := svc.VM.ListLocations(ctx)
+	if err != nil || len(locations) == 0 || locations[0].Region != "Africa" || locations[0].City != "Fez" {
+		t.Errorf("unexpected locations order: %v", err)
+	}
+	tpl, err := svc.VM.GetTemplateByName(ctx, "Ubuntu 24.04.3 64bits")
+	if err != nil || tpl.Id != 1194 {
+		t.Errorf("unexpected template: %+v, %v", tpl, err)
+	}
+}
+
+func TestServer_instanceLifecycle(t *testing.T) {
+	srv, svc := newService(t)
+	ctx := context.Background()
+
+	created, err := svc.VM.CreateInstance(ctx, &vm.InstanceCreateRequest{
+		LocationId:     33,
+		InstanceSizeId: 45,
+		TemplateId:     "1194",
+		Hostname:       "fake",
+		SshKeys:        []string{"key-1"},
+		UserData:       "I2Nsb3VkLWNvbmZpZwo=",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating instance: %v", err)
+	}
+	id := created.Response.Id
+
+	info, err := svc.VM.GetInstanceByID(ctx, id)
+	if err != nil {
+		t.Fatalf("unexpected error reading instance: %v", err)
+	}
+	if !info.Response.ServerInstall || info.Response.ServerInfo.IpAddress != "" {
+		t.Errorf("expected instance to be installing first, got %+v", info.Response)
+	}
+
+	info, err = svc.VM.GetInstanceByID(ctx, id)
+	if err != nil {
+		t.Fatalf("unexpected error reading instance: %v", err)
+	}
+	got := info.Response.ServerInfo
+	if info.Response.ServerInstall || got.City != "Brussels" || got.Plan != "02d30c1" || got.Template != "Ubuntu 24.04.3 64bits" || got.IpAddress != created.Response.IpAddress {
+		t.Errorf("unexpected instance info: %+v", info.Response)
+	}
+	list, err := svc.VM.ListInstances(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error listing instances: %v", err)
+	}
+	if len(list.Response) != 1 || list.Response[0].Id != id || list.Response[0].Hostname != "fake" || list.Response[0].City != "Brussels" {
+		t.Errorf("unexpected instance list: %+v", list.Response)
+	}
+	if i, _ := srv.Instance(id); len(i.SSHKeys) != 1 || i.SSHKeys[0] != "key-1" {
+		t.Errorf("unexpected ssh keys: %v", i.SSHKeys)
+	}
+	if i, _ := srv.Instance(id); string(i.UserData) != "#cloud-config\n" {
+		t.Errorf("unexpected user data: %q", i.UserData)
+	}
+
+	if err = svc.VM.StopInstance(ctx, id); err != nil {
+		t.Fatalf("unexpected error stopping instance: %v", err)
+	}
+	if i, _ := srv.Instance(id); i.State != vm.InstanceStateOffline {
+		t.Errorf("expected instance to be offline, got %s", i.State)
+	}
+	if err = svc.VM.StartInstance(ctx, id); err != nil {
+		t.Fatalf("unexpected error starting instance: %v", err)
+	}
+	if i, _ := srv.Instance(id); i.State != vm.InstanceStateOnline {
+		t.Errorf("expected instance to be online, got %s", i.State)
+	}
+
+	err = svc.VM.ResizeInstance(ctx, &vm.InstanceResizeRequest{VmId: id, InstanceSizeId: 46})
+	if !errors.Is(err, client.ErrInvalidState) {
+		t.Errorf("expected an invalid state error resizing a running instance, got %v", err)
+	}
+	if err = svc.VM.StopInstance(ctx, id); err != nil {
+		t.Fatalf("unexpected error stopping instance: %v", err)
+	}
+	if err = svc.VM.ResizeInstance(ctx, &vm.InstanceResizeRequest{VmId: id, InstanceSizeId: 46}); err != nil {
+		t.Fatalf("unexpected error resizing instance: %v", err)
+	}
+	err = svc.VM.ResizeInstance(ctx, &vm.InstanceResizeRequest{VmId: id, InstanceSizeId: 45})
+	if !errors.Is(err, client.ErrInvalidParameter) {
+		t.Errorf("expected an invalid parameter error shrinking the disk, got %v", err)
+	}
+	if i, _ := srv.Instance(id); i.SizeID != "46" {
+		t.Errorf("expected instance size 46, got %s", i.SizeID)
+	}
+	if err = svc.VM.StartInstance(ctx, id); err != nil {
+		t.Fatalf("unexpected error starting instance: %v", err)
+	}
+
+	reset, err := svc.VM.ResetInstancePassword(ctx, &vm.InstancePasswordResetRequest{VmId: id})
+	if err != nil {
+		t.Fatalf("unexpected error resetting password: %v", err)
+	}
+	if i, _ := srv.Instance(id); reset.Response.Password == created.Response.Password || i.Password != reset.Response.Password {
+		t.Errorf("expected a new password, got %s", reset.Response.Password)
+	}
+
+	err = svc.VM.UpdateInstanceHostname(ctx, &vm.InstanceHostnameUpdateRequest{VmId: id, Hostname: "renamed"})
+	if err != nil {
+		t.Fatalf("unexpected error renaming instance: %v", err)
+	}
+	if i, _ := srv.Instance(id); i.Hostname != "renamed" {
+		t.Errorf("expected hostname renamed, got %s", i.Hostname)
+	}
+
+	if err = svc.VM.DestroyInstance(ctx, &vm.InstanceDestroyRequest{VmId: id, ConfirmClose: true}); err != nil {
+		t.Fatalf("unexpected error destroying instance: %v", err)
+	}
+	_, err = svc.VM.GetInstanceByID(ctx, id)
+	if !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("expected ErrNotFound after destroy, got %v", err)
+	}
+}
+
+func TestServer_createRejectsUnavailableSize(t *testing.T) {
+	_, svc := newService(t)
+
+	_, err := svc.VM.CreateInstance(context.Background(), &vm.InstanceCreateRequest{
+		LocationId:     33,
+		InstanceSizeId: 71,
+		TemplateId:     "1194",
+		Hostname:       "fake",
+	})
+	if !errors.Is(err, client.ErrInvalidParameter) {
+		t.Errorf("expected an invalid parameter error, got %v", err)
+	}
+}
+
+func TestServer_createWithIPv6(t *testing.T) {
+	_, svc := newService(t)
+	ctx := context.Background()
+
+	created, err := svc.VM.CreateInstance(ctx, &vm.InstanceCreateRequest{
+		LocationId:     33,
+		InstanceSizeId: 45,
+		TemplateId:     "1194",
+		Hostname:       "fake",
+		EnableIPv6:     true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating instance: %v", err)
+	}
+	var info *vm.InstanceReadResponse
+	for range oneprovidertest.DefaultInstallPolls + 1 {
+		if info, err = svc.VM.GetInstanceByID(ctx, created.Response.Id); err != nil {
+			t.Fatalf("unexpected error reading instance: %v", err)
+		}
+	}
+	if got := info.Response.ServerInfo; got.IPv6Address == "" || got.IPv6Prefix == "" || got.IpAddress != created.Response.IpAddress {
+		t.Errorf("expected an IPv6 address and prefix, got %+v", got)
+	}
+
+	// Ashburn doesn't support IPv6.
+	_, err = svc.VM.CreateInstance(ctx, &vm.InstanceCreateRequest{
+		LocationId:     171,
+		InstanceSizeId: 45,
+		TemplateId:     "1194",
+		Hostname:       "fake",
+		EnableIPv6:     true,
+	})
+	if !errors.Is(err, client.ErrInvalidParameter) {
+		t.Errorf("expected an invalid parameter error, got %v", err)
+	}
+}
+
+func TestServer_instanceSSHKeys(t *testing.T) {
+	srv, svc := newService(t)
+	ctx := context.Background()
+
+	created, err := svc.VM.CreateInstance(ctx, &vm.InstanceCreateRequest{
+		LocationId:     33,
+		InstanceSizeId: 45,
+		TemplateId:     "1194",
+		Hostname:       "fake",
+		SshKeys:        []string{"key-1"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating instance: %v", err)
+	}
+	id := created.Response.Id
+
+	if err = svc.VM.AttachInstanceSSHKey(ctx, &vm.InstanceSSHKeyRequest{VmId: id, SshKey: "key-2"}); err != nil {
+		t.Fatalf("unexpected error attaching ssh key: %v", err)
+	}
+	if err = svc.VM.DetachInstanceSSHKey(ctx, &vm.InstanceSSHKeyRequest{VmId: id, SshKey: "key-1"}); err != nil {
+		t.Fatalf("unexpected error detaching ssh key: %v", err)
+	}
+	if i, _ := srv.Instance(id); len(i.SSHKeys) != 1 || i.SSHKeys[0] != "key-2" {
+		t.Errorf("unexpected ssh keys: %v", i.SSHKeys)
+	}
+
+	err = svc.VM.DetachInstanceSSHKey(ctx, &vm.InstanceSSHKeyRequest{VmId: id, SshKey: "key-1"})
+	if !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("expected ErrNotFound detaching a detached key, got %v", err)
+	}
+	err = svc.VM.AttachInstanceSSHKey(ctx, &vm.InstanceSSHKeyRequest{VmId: "0", SshKey: "key-1"})
+	if !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("expected ErrNotFound attaching to an unknown VM, got %v", err)
+	}
+
+	var info *vm.InstanceReadResponse
+	for range oneprovidertest.DefaultInstallPolls + 1 {
+		if info, err = svc.VM.GetInstanceByID(ctx, id); err != nil {
+			t.Fatalf("unexpected error reading instance: %v", err)
+		}
+	}
+	if got := info.Response.ServerInfo.SshKeys; len(got) != 1 || got[0] != "key-2" {
+		t.Errorf("unexpected ssh keys in instance info: %v", got)
+	}
+}
+
+func TestServer_sshKeyLifecycle(t *testing.T) {
+	_, svc := newService(t)
+	ctx := context.Background()
+
+	created, err := svc.SSH.Create(ctx, &ssh.SshKeyCreateRequest{Name: "key", PublicKey: "ssh-ed25519 AAAA"})
+	if err != nil {
+		t.Fatalf("unexpected error creating key: %v", err)
+	}
+	uuid := created.Response.Key.Uuid
+
+	_, err = svc.SSH.Update(ctx, &ssh.SshKeyUpdateRequest{Uuid: uuid, Name: "renamed", PublicKey: "ssh-ed25519 BBBB"})
+	if err != nil {
+		t.Fatalf("unexpected error updating key: %v", err)
+	}
+	key, err := svc.SSH.GetByName(ctx, "renamed")
+	if err != nil || key.Uuid != uuid || key.Value != "ssh-ed25519 BBBB" {
+		t.Errorf("unexpected key: %+v, %v", key, err)
+	}
+
+	if err = svc.SSH.Destroy(ctx, uuid); err != nil {
+		t.Fatalf("unexpected error destroying key: %v", err)
+	}
+	_, err = svc.SSH.GetByID(ctx, uuid)
+	if !errors.Is(err, ssh.ErrNotFound) {
+		t.Errorf("expected ErrNotFound after destroy, got %v", err)
+	}
+}
+
+func TestServer_reverseDNS(t *testing.T) {
+	srv, svc := newService(t)
+	ctx := context.Background()
+
+	created, err := svc.VM.CreateInstance(ctx, &vm.InstanceCreateRequest{
+		LocationId:     33,
+		InstanceSizeId: 45,
+		TemplateId:     "1194",
+		Hostname:       "fake",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating instance: %v", err)
+	}
+	address := created.Response.IpAddress
+
+	rdns, err := svc.IP.GetReverseDNS(ctx, address)
+	if err != nil {
+		t.Fatalf("unexpected error reading reverse dns: %v", err)
+	}
+	defaultHostname := rdns.Response.Hostname
+
+	if err = svc.IP.SetReverseDNS(ctx, &ip.ReverseDNSUpdateRequest{IpAddress: address, Hostname: "mail.example.com"}); err != nil {
+		t.Fatalf("unexpected error setting reverse dns: %v", err)
+	}
+	if hostname, _ := srv.ReverseDNS(address); hostname != "mail.example.com" {
+		t.Errorf("expected mail.example.com, got %s", hostname)
+	}
+
+	if err = svc.IP.ResetReverseDNS(ctx, address); err != nil {
+		t.Fatalf("unexpected error resetting reverse dns: %v", err)
+	}
+	rdns, err = svc.IP.GetReverseDNS(ctx, address)
+	if err != nil || rdns.Response.Hostname != defaultHostname {
+		t.Errorf("expected reverse dns to be reset to %s, got %+v, %v", defaultHostname, rdns, err)
+	}
+
+	_, err = svc.IP.GetReverseDNS(ctx, "198.51.100.1")
+	if !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown IP address, got %v", err)
+	}
+}
+
+func TestServer_snapshotLifecycle(t *testing.T) {
+	srv, svc := newService(t)
+	ctx := context.Background()
+
+	created, err := svc.VM.CreateInstance(ctx, &vm.InstanceCreateRequest{
+		LocationId:     33,
+		InstanceSizeId: 45,
+		TemplateId:     "1194",
+		Hostname:       "golden",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating instance: %v", err)
+	}
+	id := created.Response.Id
+
+	_, err = svc.VM.CreateSnapshot(ctx, &vm.SnapshotCreateRequest{VmId: id, Name: "golden"})
+	if !errors.Is(err, client.ErrInvalidState) {
+		t.Errorf("expected an invalid state error snapshotting an installing instance, got %v", err)
+	}
+	if _, err = svc.VM.GetInstanceByID(ctx, id); err != nil {
+		t.Fatalf("unexpected error reading instance: %v", err)
+	}
+	snapshot, err := svc.VM.CreateSnapshot(ctx, &vm.SnapshotCreateRequest{VmId: id, Name: "golden"})
+	if err != nil {
+		t.Fatalf("unexpected error creating snapshot: %v", err)
+	}
+	snapshotID := snapshot.Response.Id
+
+	got, err := svc.VM.GetSnapshotByID(ctx, snapshotID)
+	if err != nil || got.Status != vm.SnapshotStatusCreating || got.VmId != id || got.Name != "golden" {
+		t.Errorf("expected snapshot to be creating first, got %+v, %v", got, err)
+	}
+	_, err = svc.VM.CreateInstance(ctx, &vm.InstanceCreateRequest{LocationId: 33, InstanceSizeId: 45, SnapshotId: snapshotID, Hostname: "clone"})
+	if !errors.Is(err, client.ErrInvalidParameter) {
+		t.Errorf("expected an invalid parameter error cloning a snapshot being created, got %v", err)
+	}
+	got, err = svc.VM.GetSnapshotByID(ctx, snapshotID)
+	if err != nil || got.Status != vm.SnapshotStatusAvailable || got.Size == 0 || got.CreatedAt == "" {
+		t.Errorf("expected snapshot to be available, got %+v, %v", got, err)
+	}
+
+	clone, err := svc.VM.CreateInstance(ctx, &vm.InstanceCreateRequest{LocationId: 33, InstanceSizeId: 45, SnapshotId: snapshotID, Hostname: "clone"})
+	if err != nil {
+		t.Fatalf("unexpected error cloning snapshot: %v", err)
+	}
+	if i, _ := srv.Instance(clone.Response.Id); i.TemplateID != 1194 {
+		t.Errorf("expected the clone to use the snapshot template, got %d", i.TemplateID)
+	}
+
+	if err = svc.VM.RestoreSnapshot(ctx, &vm.SnapshotRestoreRequest{VmId: clone.Response.Id, SnapshotId: snapshotID}); !errors.Is(err, client.ErrInvalidParameter) {
+		t.Errorf("expected an invalid parameter error restoring another VM snapshot, got %v", err)
+	}
+	if err = svc.VM.RestoreSnapshot(ctx, &vm.SnapshotRestoreRequest{VmId: id, SnapshotId: snapshotID}); err != nil {
+		t.Fatalf("unexpected error restoring snapshot: %v", err)
+	}
+	info, err := svc.VM.GetInstanceByID(ctx, id)
+	if err != nil || !info.Response.ServerInstall {
+		t.Errorf("expected instance to be installing while restoring, got %+v, %v", info, err)
+	}
+
+	if err = svc.VM.DeleteSnapshot(ctx, snapshotID); err != nil {
+		t.Fatalf("unexpected error deleting snapshot: %v", err)
+	}
+	_, err = svc.VM.GetSnapshotByID(ctx, snapshotID)
+	if !errors.Is(err, vm.ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+	if err = svc.VM.DeleteSnapshot(ctx, snapshotID); !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("expected ErrNotFound deleting twice, got %v", err)
+	}
+}
+
+func TestServer_dedicatedServerLifecycle(t *testing.T) {
+	srv, svc := newService(t)
+	ctx := context.Background()
+
+	id := srv.AddDedicatedServer("db-1")
+
+	got, err := svc.Server.GetServerByHostname(ctx, "db-1")
+	if err != nil || got.Id != id || got.Os != "Debian 12 64bits" {
+		t.Errorf("unexpected server: %+v, %v", got, err)
+	}
+	info, err := svc.Server.GetServerByID(ctx, id)
+	if err != nil || info.Response.ServerInstall || info.Response.ServerInfo.OsId != "21" || info.Response.ServerState.State != server.StateOnline {
+		t.Errorf("unexpected server info: %+v, %v", info, err)
+	}
+	rdns, err := svc.IP.GetReverseDNS(ctx, info.Response.ServerInfo.IpAddress)
+	if err != nil || rdns.Response.Hostname == "" {
+		t.Errorf("expected the IP address of the server to belong to the account, got %+v, %v", rdns, err)
+	}
+
+	if err = svc.Server.UpdateServerHostname(ctx, &server.ServerHostnameUpdateRequest{ServerId: id, Hostname: "db-2"}); err != nil {
+		t.Fatalf("unexpected error renaming server: %v", err)
+	}
+	if s, _ := srv.DedicatedServer(id); s.Hostname != "db-2" {
+		t.Errorf("expected hostname db-2, got %s", s.Hostname)
+	}
+	if err = svc.Server.RebootServer(ctx, id); err != nil {
+		t.Fatalf("unexpected error rebooting server: %v", err)
+	}
+
+	_, err = svc.Server.ReinstallServer(ctx, &server.ServerReinstallRequest{ServerId: id, OsId: "1"})
+	if !errors.Is(err, client.ErrInvalidParameter) {
+		t.Errorf("expected an invalid parameter error for an unknown OS, got %v", err)
+	}
+	reinstall, err := svc.Server.ReinstallServer(ctx, &server.ServerReinstallRequest{ServerId: id, OsId: "25", SshKeys: []string{"key-1"}})
+	if err != nil {
+		t.Fatalf("unexpected error reinstalling server: %v", err)
+	}
+	if s, _ := srv.DedicatedServer(id); s.Password != reinstall.Response.Password || len(s.SSHKeys) != 1 {
+		t.Errorf("unexpected server after reinstall: %+v", s)
+	}
+	_, err = svc.Server.ReinstallServer(ctx, &server.ServerReinstallRequest{ServerId: id, OsId: "25"})
+	if !errors.Is(err, client.ErrInvalidState) {
+		t.Errorf("expected an invalid state error reinstalling a server being installed, got %v", err)
+	}
+	info, err = svc.Server.GetServerByID(ctx, id)
+	if err != nil || !info.Response.ServerInstall {
+		t.Errorf("expected server to be installing first, got %+v, %v", info, err)
+	}
+	info, err = svc.Server.GetServerByID(ctx, id)
+	if err != nil || info.Response.ServerInstall || info.Response.ServerInfo.Os != "Ubuntu 24.04 64bits" {
+		t.Errorf("unexpected server info after reinstall: %+v, %v", info, err)
+	}
+
+	_, err = svc.Server.GetServerByID(ctx, "unknown")
+	if !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown server, got %v", err)
+	}
+	_, err = svc.Server.GetServerByHostname(ctx, "unknown")
+	if !errors.Is(err, server.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown hostname, got %v", err)
+	}
+}
+
+func TestServer_addressLifecycle(t *testing.T) {
+	srv, svc := newService(t)
+	ctx := context.Background()
+
+	var vms []string
+	for _, hostname := range []string{"blue", "green"} {
+		created, err := svc.VM.CreateInstance(ctx, &vm.InstanceCreateRequest{
+			LocationId:     33,
+			InstanceSizeId: 45,
+			TemplateId:     "1194",
+			Hostname:       hostname,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error creating instance: %v", err)
+		}
+		vms = append(vms, created.Response.Id)
+	}
+
+	_, err := svc.IP.AllocateAddress(ctx, &ip.AddressAllocateRequest{LocationId: "86", Type: ip.TypeIPv4})
+	if !errors.Is(err, client.ErrInvalidParameter) {
+		t.Errorf("expected an invalid parameter error allocating IPv4 in Oslo, got %v", err)
+	}
+	allocated, err := svc.IP.AllocateAddress(ctx, &ip.AddressAllocateRequest{LocationId: "33", Type: ip.TypeIPv4})
+	if err != nil {
+		t.Fatalf("unexpected error allocating address: %v", err)
+	}
+	id := allocated.Response.Id
+
+	if err = svc.IP.AssignAddress(ctx, &ip.AddressAssignRequest{IpId: id, VmId: vms[0]}); err != nil {
+		t.Fatalf("unexpected error assigning address: %v", err)
+	}
+	if err = svc.IP.ReleaseAddress(ctx, id); !errors.Is(err, client.ErrInvalidState) {
+		t.Errorf("expected an invalid state error releasing an assigned address, got %v", err)
+	}
+	// The address moves to the other VM without being unassigned.
+	if err = svc.IP.AssignAddress(ctx, &ip.AddressAssignRequest{IpId: id, VmId: vms[1]}); err != nil {
+		t.Fatalf("unexpected error moving address: %v", err)
+	}
+	got, err := svc.IP.GetAddressByID(ctx, id)
+	if err != nil || got.VmId != vms[1] || got.IpAddress != allocated.Response.IpAddress || got.Type != ip.TypeIPv4 {
+		t.Errorf("unexpected address: %+v, %v", got, err)
+	}
+	if _, err = svc.IP.GetReverseDNS(ctx, got.IpAddress); err != nil {
+		t.Errorf("expected the address to belong to the account, got %v", err)
+	}
+
+	if err = svc.IP.UnassignAddress(ctx, id); err != nil {
+		t.Fatalf("unexpected error unassigning address: %v", err)
+	}
+	if err = svc.IP.ReleaseAddress(ctx, id); err != nil {
+		t.Fatalf("unexpected error releasing address: %v", err)
+	}
+	if len(srv.Addresses()) != 0 {
+		t.Errorf("expected no address left, got %+v", srv.Addresses())
+	}
+	_, err = svc.IP.GetAddressByID(ctx, id)
+	if !errors.Is(err, ip.ErrNotFound) {
+		t.Errorf("expected ErrNotFound after release, got %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package oneprovidertest
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/client"
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/vm"
+)
+
+// Snapshot is the fake's view of a VM snapshot. Snapshots outlive their VM.
+type Snapshot struct {
+	ID           string
+	VMID         string
+	Name         string
+	TemplateID   int
+	SizeGB       int
+	Status       string
+	CreatedAt    time.Time
+	pendingPolls int
+}
+
+// Snapshots returns a copy of the stored snapshots.
+func (s *Server) Snapshots() []Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshots := make([]Snapshot, 0, len(s.snapshots))
+	for _, sn := range s.snapshots {
+		snapshots = append(snapshots, *sn)
+	}
+	return snapshots
+}
+
+// findSnapshot returns the snapshot with the given ID. Callers must hold s.mu.
+func (s *Server) findSnapshot(id string) (*Snapshot, bool) {
+	idx := slices.IndexFunc(s.snapshots, func(sn *Snapshot) bool { return sn.ID == id })
+	if idx == -1 {
+		return nil, false
+	}
+	return s.snapshots[idx], true
+}
+
+// handleSnapshotList reports new snapshots as creating for SnapshotPolls calls.
+func (s *Server) handleSnapshotList(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := vm.SnapshotsListResponse{Response: []vm.SnapshotReadResponse{}}
+	for _, sn := range s.snapshots {
+		if sn.pendingPolls > 0 {
+			sn.pendingPolls--
+		} else {
+			sn.Status = vm.SnapshotStatusAvailable
+		}
+		resp.Response = append(resp.Response, vm.SnapshotReadResponse{
+			Id:        sn.ID,
+			VmId:      sn.VMID,
+			Name:      sn.Name,
+			Size:      sn.SizeGB,
+			Status:    sn.Status,
+			CreatedAt: sn.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	writeJSON(w, resp)
+}
+
+func (s *Server) handleSnapshotCreate(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, client.CodeInvalidParameter, err.Error())
+		return
+	}
+	name := r.PostForm.Get("name")
+	if name == "" {
+		writeError(w, client.CodeInvalidParameter, "Missing name")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instance, ok := s.instances[r.PostForm.Get("vm_id")]
+	if !ok {
+		writeError(w, client.CodeNotFound, "VM not found")
+		return
+	}
+	if instance.installPolls > 0 {
+		writeError(w, client.CodeInvalidState, "VM is being installed")
+		return
+	}
+	size, _ := s.findSize(instance.SizeID)
+	disk, _ := strconv.Atoi(size.Disk)
+
+	s.nextID++
+	snapshot := &Snapshot{
+		ID:           strconv.Itoa(s.nextID),
+		VMID:         instance.ID,
+		Name:         name,
+		TemplateID:   instance.TemplateID,
+		SizeGB:       disk,
+		Status:       vm.SnapshotStatusCreating,
+		CreatedAt:    time.Now().UTC().Truncate(time.Second),
+		pendingPolls: s.SnapshotPolls,
+	}
+	s.snapshots = append(s.snapshots, snapshot)
+
+	var resp vm.SnapshotCreateResponse
+	resp.Response.Message = "Snapshot is being created"
+	resp.Response.Id = snapshot.ID
+	writeJSON(w, resp)
+}
+
+func (s *Server) handleSnapshotDelete(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, client.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PostForm.Get("snapshot_id")
+	if _, ok := s.findSnapshot(id); !ok {
+		writeError(w, client.CodeNotFound, "Snapshot not found")
+		return
+	}
+	s.snapshots = slices.DeleteFunc(s.snapshots, func(sn *Snapshot) bool { return sn.ID == id })
+	writeSuccess(w)
+}
+
+// handleSnapshotRestore only restores available snapshots of the same VM.
+func (s *Server) handleSnapshotRestore(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, client.CodeInvalidParameter, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	instance, ok := s.instances[r.PostForm.Get("vm_id")]
+	if !ok {
+		writeError(w, client.CodeNotFound, "VM not found")
+		return
+	}
+	snapshot, ok := s.findSnapshot(r.PostForm.Get("snapshot_id"))
+	if !ok {
+		writeError(w, client.CodeNotFound, "Snapshot not found")
+		return
+	}
+	if snapshot.VMID != instance.ID {
+		writeError(w, client.CodeInvalidParameter, "Snapshot does not belong to this VM")
+		return
+	}
+	if snapshot.Status != vm.SnapshotStatusAvailable {
+		writeError(w, client.CodeInvalidState, "Snapshot is not available yet")
+		return
+	}
+	instance.TemplateID = snapshot.TemplateID
+	instance.State, instance.Status = vm.InstanceStateOnline, "running"
+	instance.installPolls = s.InstallPolls
+	writeSuccess(w)
+}
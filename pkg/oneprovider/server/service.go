@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/client"
+)
+
+// ErrNotFound is returned by Get* methods when the requested dedicated server does not exist.
+// It also matches client.ErrNotFound, like the API error returned for unknown server IDs.
+var ErrNotFound = fmt.Errorf("server: %w", client.ErrNotFound)
+
+// Service manages the dedicated servers of the account. Dedicated servers are
+// ordered out of band, so there is no call to create or cancel them.
+type Service struct {
+	client *client.Client
+}
+
+func NewService(c *client.Client) *Service {
+	return &Service{client: c}
+}
+
+// ListServers returns every dedicated server of the account.
+func (s *Service) ListServers(ctx context.Context) (*ServersListResponse, error) {
+	var response ServersListResponse
+	err := s.client.MakeAPICall(ctx, http.MethodGet, "/server/list", nil, &response)
+	if err != nil {
+		return nil, fmt.Errorf("server: list servers failed: %w", err)
+	}
+
+	return &response, nil
+}
+
+func (s *Service) GetServerByID(ctx context.Context, id string) (*ServerReadResponse, error) {
+	var response ServerReadResponse
+	err := s.client.MakeAPICall(ctx, http.MethodGet, fmt.Sprintf("/server/info/%s", id), nil, &response)
+	if err != nil {
+		return nil, fmt.Errorf("server: get server by ID failed: %w", err)
+	}
+
+	return &response, nil
+}
+
+// GetServerByHostname returns the only dedicated server with the given hostname.
+func (s *Service) GetServerByHostname(ctx context.Context, hostname string) (*ServerSummary, error) {
+	list, err := s.ListServers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []ServerSummary
+	for _, srv := range list.Response {
+		if strings.EqualFold(srv.Hostname, hostname) {
+			found = append(found, srv)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("server: server not found for hostname %s: %w", hostname, ErrNotFound)
+	case 1:
+		return &found[0], nil
+	default:
+		return nil, fmt.Errorf("server: %d servers found for hostname %s", len(found), hostname)
+	}
+}
+
+func (s *Service) UpdateServerHostname(ctx context.Context, req *ServerHostnameUpdateRequest) error {
+	err := s.client.MakeAPICall(ctx, http.MethodPost, "/server/hostname", strings.NewReader(req.UrlValues().Encode()), nil)
+	if err != nil {
+		return fmt.Errorf("server: update server hostname failed: %w", err)
+	}
+	return nil
+}
+
+// ReinstallServer installs another OS on the dedicated server, wiping its disks.
+// The returned password replaces the previous root password.
+func (s *Service) ReinstallServer(ctx context.Context, req *ServerReinstallRequest) (*ServerReinstallResponse, error) {
+	var response ServerReinstallResponse
+
+	err := s.client.MakeAPICall(ctx, http.MethodPost, "/server/reinstall", strings.NewReader(req.UrlValues().Encode()), &response)
+	if err != nil {
+		return nil, fmt.Errorf("server: reinstall server failed: %w", err)
+	}
+
+	return &response, nil
+}
+
+func (s *Service) RebootServer(ctx context.Context, id string) error {
+	req := &ServerActionRequest{ServerId: id, Action: ServerActionReboot}
+	err := s.client.MakeAPICall(ctx, http.MethodPost, "/server/action", strings.NewReader(req.UrlValues().Encode()), nil)
+	if err != nil {
+		return fmt.Errorf("server: reboot server failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// States reported by the API in server_state.state.
+const (
+	StateOnline  = "online"
+	StateOffline = "offline"
+)
+
+type ServersListResponse struct {
+	Response []ServerSummary `json:"response"`
+}
+
+// ServerSummary is a dedicated server as listed by /server/list.
+type ServerSummary struct {
+	Id        string `json:"id"`
+	Hostname  string `json:"hostname"`
+	IpAddress string `json:"ipaddress"`
+	Location  string `json:"location"`
+	Model     string `json:"model"`
+	Os        string `json:"os"`
+	Status    string `json:"status"`
+	State     string `json:"state"`
+}
+
+type ServerReadResponse struct {
+	Response struct {
+		ServerInstall bool `json:"server_install"`
+		ServerInfo    struct {
+			IpAddress string `json:"ipaddress"`
+			Hostname  string `json:"hostname"`
+			Location  string `json:"location"`
+			Model     string `json:"model"`
+			Cpu       string `json:"cpu"`
+			Ram       string `json:"ram"`
+			Storage   string `json:"storage"`
+			OsId      string `json:"os_id"`
+			Os        string `json:"os"`
+		} `json:"server_info"`
+		ServerState struct {
+			Status string `json:"status"`
+			State  string `json:"state"`
+		} `json:"server_state"`
+	} `json:"response"`
+}
+
+type ServerHostnameUpdateRequest struct {
+	ServerId string `json:"server_id"`
+	Hostname string `json:"hostname"`
+}
+
+func (v *ServerHostnameUpdateRequest) UrlValues() url.Values {
+	return url.Values{
+		"server_id": {v.ServerId},
+		"hostname":  {v.Hostname},
+	}
+}
+
+type ServerReinstallRequest struct {
+	ServerId string   `json:"server_id"`
+	OsId     string   `json:"os"`
+	Hostname string   `json:"hostname"`
+	SshKeys  []string `json:"ssh_keys"`
+}
+
+func (v *ServerReinstallRequest) UrlValues() url.Values {
+	urlValues := url.Values{
+		"server_id": {v.ServerId},
+		"os":        {v.OsId},
+	}
+	if v.Hostname != "" {
+		urlValues.Set("hostname", v.Hostname)
+	}
+	for idx, key := range v.SshKeys {
+		urlValues.Add(fmt.Sprintf("ssh_keys[%d]", idx), key)
+	}
+	return urlValues
+}
+
+type ServerReinstallResponse struct {
+	Response struct {
+		Message  string `json:"message"`
+		Password string `json:"password"`
+	} `json:"response"`
+}
+
+// Actions accepted by /server/action.
+const (
+	ServerActionReboot = "reboot"
+)
+
+type ServerActionRequest struct {
+	ServerId string `json:"server_id"`
+	Action   string `json:"action"`
+}
+
+func (v *ServerActionRequest) UrlValues() url.Values {
+	return url.Values{
+		"server_id": {v.ServerId},
+		"action":    {v.Action},
+	}
+}
@@ -1,23 +1,59 @@
 package oneprovider
 
 import (
+	"time"
+
 	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/client"
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/ip"
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/server"
 	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/ssh"
 	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/vm"
 )
 
 type Service struct {
-	VM  *vm.Service
-	SSH *ssh.Service
+	VM     *vm.Service
+	SSH    *ssh.Service
+	IP     *ip.Service
+	Server *server.Service
+}
+
+type options struct {
+	clientOptions   []client.Option
+	catalogCacheTTL time.Duration
+}
+
+// Option customizes a Service created with NewService.
+type Option func(*options)
+
+// WithClientOptions forwards opts to the underlying client.Client.
+func WithClientOptions(opts ...client.Option) Option {
+	return func(o *options) {
+		o.clientOptions = append(o.clientOptions, opts...)
+	}
+}
+
+// WithCatalogCacheTTL sets how long templates, locations and sizes are cached.
+// A zero or negative TTL disables the cache.
+func WithCatalogCacheTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.catalogCacheTTL = ttl
+	}
 }
 
-func NewService(endpoint, apiKey, clientKey string) (*Service, error) {
-	c, err := client.NewClient(endpoint, apiKey, clientKey)
+func NewService(endpoint, apiKey, clientKey string, opts ...Option) (*Service, error) {
+	o := options{catalogCacheTTL: vm.DefaultCatalogCacheTTL}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	c, err := client.NewClient(endpoint, apiKey, clientKey, o.clientOptions...)
 	if err != nil {
 		return nil, err
 	}
 	return &Service{
-		VM:  vm.NewService(c),
-		SSH: ssh.NewService(c),
+		VM:     vm.NewService(c, o.catalogCacheTTL),
+		SSH:    ssh.NewService(c),
+		IP:     ip.NewService(c),
+		Server: server.NewService(c),
 	}, nil
 }
@@ -0,0 +1,105 @@
+package ssh
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SupportedKeyTypes lists the public key algorithms accepted by ParsePublicKey.
+var SupportedKeyTypes = []string{
+	"ssh-rsa",
+	"ssh-ed25519",
+	"ecdsa-sha2-nistp256",
+	"ecdsa-sha2-nistp384",
+	"ecdsa-sha2-nistp521",
+	"sk-ssh-ed25519@openssh.com",
+	"sk-ecdsa-sha2-nistp256@openssh.com",
+}
+
+// PublicKey is a parsed authorized_keys formatted public key.
+type PublicKey struct {
+	Type    string
+	Blob    []byte
+	Comment string
+}
+
+// ParsePublicKey parses a public key in the authorized_keys format,
+// i.e. "<type> <base64> [comment]". Surrounding whitespace is ignored.
+// The type must be one of SupportedKeyTypes and match the one encoded in the key blob.
+func ParsePublicKey(s string) (*PublicKey, error) {
+	fields := strings.Fields(s)
+	if len(fields) < 2 {
+		return nil, errors.New("ssh: malformed public key: expected \"<type> <base64> [comment]\"")
+	}
+	if !slices.Contains(SupportedKeyTypes, fields[0]) {
+		return nil, fmt.Errorf("ssh: unsupported public key type %q, expected one of %s", fields[0], strings.Join(SupportedKeyTypes, ", "))
+	}
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return nil, fmt.Errorf("ssh: malformed public key: %w", err)
+	}
+	// The blob starts with the key type as a length-prefixed string.
+	if len(blob) < 4 {
+		return nil, errors.New("ssh: malformed public key: blob is too short")
+	}
+	n := binary.BigEndian.Uint32(blob)
+	if uint64(len(blob)-4) <= uint64(n) {
+		return nil, errors.New("ssh: malformed public key: blob is too short")
+	}
+	if blobType := string(blob[4 : 4+n]); blobType != fields[0] {
+		return nil, fmt.Errorf("ssh: malformed public key: type %q does not match the encoded type %q", fields[0], blobType)
+	}
+	return &PublicKey{
+		Type:    fields[0],
+		Blob:    blob,
+		Comment: strings.Join(fields[2:], " "),
+	}, nil
+}
+
+// Equal reports whether both keys are the same, regardless of their comment.
+func (k *PublicKey) Equal(other *PublicKey) bool {
+	return k.Type == other.Type && slices.Equal(k.Blob, other.Blob)
+}
+
+// String returns the key in the authorized_keys format, with single spaces between fields.
+func (k *PublicKey) String() string {
+	s := k.Type + " " + base64.StdEncoding.EncodeToString(k.Blob)
+	if k.Comment != "" {
+		s += " " + k.Comment
+	}
+	return s
+}
+
+// FingerprintSHA256 returns the fingerprint as printed by ssh-keygen -l,
+// e.g. "SHA256:uNiVztksCsDhcc0u9e8BujQXVUpKZIDTMczCvj3tD2s".
+func (k *PublicKey) FingerprintSHA256() string {
+	sum := sha256.Sum256(k.Blob)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
+
+// FingerprintMD5 returns the fingerprint as printed by ssh-keygen -l -E md5,
+// e.g. "MD5:3c:e0:ff:50:59:0c:5e:23:76:81:45:d6:00:8c:5a:ba".
+func (k *PublicKey) FingerprintMD5() string {
+	sum := md5.Sum(k.Blob)
+	hex := make([]string, len(sum))
+	for i, b := range sum {
+		hex[i] = fmt.Sprintf("%02x", b)
+	}
+	return "MD5:" + strings.Join(hex, ":")
+}
+
+// Fingerprint returns the SHA256 fingerprint of an authorized_keys formatted
+// public key, as printed by ssh-keygen -l, e.g. "SHA256:uNiVztksCsDhcc0u9e8BujQXVUpKZIDTMczCvj3tD2s".
+func Fingerprint(publicKey string) (string, error) {
+	k, err := ParsePublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+	return k.FingerprintSHA256(), nil
+}
@@ -0,0 +1,113 @@
+package ssh
+
+import "testing"
+
+const (
+	testEd25519Key = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKYsOrYIlOxNctNk/K/LHMVZRLs47n9JOU0UBpuiFCQR test@example"
+	testRSAKey     = "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQChgPts28r/g0DidpStT8RFPTY+55HUi9svLx07JpUNQnAo8TSbNzln8DANEF2EjEiv/MRAPc95Mm1YlAvwrfTXv7JSyudqxX+a6/pmpgl7hRz4D5KS7RzSrPCuq3YfJIijeaoaT7o+LYYZAdxx5RrDJP9OL1Vr2tWmVLz7EaFHjQtxDFaZR7MVz7+TibpJ1WO5zNGnhpL+kX/2mZhDnL4HPQ8G/cP7zqvmfwhASMSoe3QVubPL4v2CoG/rwT8azzGvbyS8G9AFArLwTWCvnVmBP9plqddNEm0BJaKwUaQzOAM63Qf1HhJm6tB9jGAVBjm9Ze2mu3yNZcolFnTRkK3l rsa comment"
+	testECDSAKey   = "ecdsa-sha2-nistp256 AAAAE2VjZHNhLXNoYTItbmlzdHAyNTYAAAAIbmlzdHAyNTYAAABBBEuNwXGDFOwgJRwmmO/6bh95zEVCIFyQsLL/wPYHEZqEJ9rbA4DRIQr74w97zvFzkIXCh5Ow4YtRMK4cg99B7UI="
+)
+
+func TestParsePublicKey(t *testing.T) {
+	tests := []struct {
+		key     string
+		keyType string
+		comment string
+		sha256  string
+		md5     string
+	}{
+		{
+			key:     testEd25519Key,
+			keyType: "ssh-ed25519",
+			comment: "test@example",
+			sha256:  "SHA256:5CgqPt7QqNpO53E700nK6U+TpzpeCKUb/AW83CUIoVg",
+			md5:     "MD5:3c:e0:ff:50:59:0c:5e:23:76:81:45:d6:00:8c:5a:ba",
+		},
+		{
+			key:     testRSAKey,
+			keyType: "ssh-rsa",
+			comment: "rsa comment",
+			sha256:  "SHA256:ScI6xslCrKlLKorCCNj1v8jS8pOqHII16fSZvu/9JKI",
+			md5:     "MD5:d6:04:34:6c:0f:e7:07:09:db:80:7c:d1:5b:c6:f8:e8",
+		},
+		{
+			key:     "  " + testECDSAKey + "\n",
+			keyType: "ecdsa-sha2-nistp256",
+			sha256:  "SHA256:Bye38wu6B7eNO620UHIkOE1iC9lvBc1fipQsWbeq6uw",
+			md5:     "MD5:13:0b:f3:1e:57:e8:f3:82:94:0c:15:ad:1a:21:7a:e7",
+		},
+	}
+	for _, tt := range tests {
+		k, err := ParsePublicKey(tt.key)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", tt.key, err)
+		}
+		if k.Type != tt.keyType {
+			t.Errorf("expected type %s, got %s", tt.keyType, k.Type)
+		}
+		if k.Comment != tt.comment {
+			t.Errorf("expected comment %q, got %q", tt.comment, k.Comment)
+		}
+		if got := k.FingerprintSHA256(); got != tt.sha256 {
+			t.Errorf("expected %s, got %s", tt.sha256, got)
+		}
+		if got := k.FingerprintMD5(); got != tt.md5 {
+			t.Errorf("expected %s, got %s", tt.md5, got)
+		}
+	}
+
+	for _, key := range []string{
+		"",
+		"ssh-ed25519",
+		"ssh-ed25519 not-base64!",
+		"ssh-dss AAAAB3NzaC1kc3M=",
+		"ssh-ed25519 AAAA",
+		// Valid ssh-rsa blob announced as ssh-ed25519.
+		"ssh-ed25519 AAAAB3NzaC1yc2EAAAADAQABAAABAQChgPts28r/g0DidpStT8RFPTY+55HUi9svLx07JpUNQnAo8TSbNzln8DANEF2EjEiv/MRAPc95Mm1YlAvwrfTXv7JSyudqxX+a6/pmpgl7hRz4D5KS7RzSrPCuq3YfJIijeaoaT7o+LYYZAdxx5RrDJP9OL1Vr2tWmVLz7EaFHjQtxDFaZR7MVz7+TibpJ1WO5zNGnhpL+kX/2mZhDnL4HPQ8G/cP7zqvmfwhASMSoe3QVubPL4v2CoG/rwT8azzGvbyS8G9AFArLwTWCvnVmBP9plqddNEm0BJaKwUaQzOAM63Qf1HhJm6tB9jGAVBjm9Ze2mu3yNZcolFnTRkK3l",
+	} {
+		if _, err := ParsePublicKey(key); err == nil {
+			t.Errorf("expected an error for %q", key)
+		}
+	}
+}
+
+func TestPublicKeyEqual(t *testing.T) {
+	a, err := ParsePublicKey(testEd25519Key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := ParsePublicKey("ssh-ed25519   AAAAC3NzaC1lZDI1NTE5AAAAIKYsOrYIlOxNctNk/K/LHMVZRLs47n9JOU0UBpuiFCQR  another comment\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !a.Equal(b) {
+		t.Errorf("expected keys differing only by comment and whitespace to be equal")
+	}
+	if got, want := b.String(), "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKYsOrYIlOxNctNk/K/LHMVZRLs47n9JOU0UBpuiFCQR another comment"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	c, err := ParsePublicKey(testRSAKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.Equal(c) {
+		t.Errorf("expected different keys not to be equal")
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	got, err := Fingerprint(testEd25519Key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "SHA256:5CgqPt7QqNpO53E700nK6U+TpzpeCKUb/AW83CUIoVg"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	for _, key := range []string{"", "ssh-ed25519", "ssh-ed25519 not-base64!"} {
+		if _, err := Fingerprint(key); err == nil {
+			t.Errorf("expected an error for %q", key)
+		}
+	}
+}
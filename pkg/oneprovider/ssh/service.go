@@ -2,7 +2,6 @@ package ssh
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -13,7 +12,8 @@ import (
 )
 
 // ErrNotFound is returned by Get* methods when the requested resource does not exist.
-var ErrNotFound = errors.New("ssh: not found")
+// It also matches client.ErrNotFound.
+var ErrNotFound = fmt.Errorf("ssh: %w", client.ErrNotFound)
 
 type Service struct {
 	client *client.Client
@@ -23,7 +23,8 @@ func NewService(c *client.Client) *Service {
 	return &Service{client: c}
 }
 
-func (s *Service) GetByID(ctx context.Context, id string) (*SshKeyReadResponse, error) {
+// List returns every SSH key of the account.
+func (s *Service) List(ctx context.Context) ([]SshKeyReadResponse, error) {
 	var resp SshKeyListResponse
 
 	err := s.client.MakeAPICall(ctx, http.MethodGet, "/vm/sshkeys/list", nil, &resp)
@@ -31,7 +32,16 @@ func (s *Service) GetByID(ctx context.Context, id string) (*SshKeyReadResponse,
 		return nil, fmt.Errorf("ssh: list ssh keys failed: %w", err)
 	}
 
-	key, found := common.FindElement(resp.Response.SshKeys, func(k SshKeyReadResponse) bool {
+	return resp.Response.SshKeys, nil
+}
+
+func (s *Service) GetByID(ctx context.Context, id string) (*SshKeyReadResponse, error) {
+	keys, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key, found := common.FindElement(keys, func(k SshKeyReadResponse) bool {
 		return id == k.Uuid
 	})
 
@@ -43,16 +53,14 @@ func (s *Service) GetByID(ctx context.Context, id string) (*SshKeyReadResponse,
 }
 
 func (s *Service) GetByName(ctx context.Context, name string) (*SshKeyReadResponse, error) {
-	var resp SshKeyListResponse
-
-	err := s.client.MakeAPICall(ctx, http.MethodGet, "/vm/sshkeys/list", nil, &resp)
+	keys, err := s.List(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("ssh: list ssh keys failed: %w", err)
+		return nil, err
 	}
-	key, found := common.FindElement(resp.Response.SshKeys, func(k SshKeyReadResponse) bool { return name == k.Name })
+	key, found := common.FindElement(keys, func(k SshKeyReadResponse) bool { return name == k.Name })
 
 	if !found {
-		return nil, fmt.Errorf("ssh: key not found for name %s", name)
+		return nil, fmt.Errorf("ssh: key not found for name %s: %w", name, ErrNotFound)
 	}
 
 	return &key, nil
@@ -0,0 +1,93 @@
+package vm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const DefaultCatalogCacheTTL = 5 * time.Minute
+
+// catalogCache keeps the templates, locations and sizes catalogs in memory.
+// Concurrent fetches of the same catalog are coalesced into a single API call.
+type catalogCache struct {
+	ttl   time.Duration
+	group singleflight.Group
+	now   func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]catalogEntry
+}
+
+type catalogEntry struct {
+	value     any
+	expiresAt time.Time
+}
+
+func newCatalogCache(ttl time.Duration) *catalogCache {
+	return &catalogCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: map[string]catalogEntry{},
+	}
+}
+
+func (c *catalogCache) get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok || !c.now().Before(e.expiresAt) {
+		return nil, false
+	}
+	return e.value, true
+}
+
+func (c *catalogCache) set(key string, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = catalogEntry{value: value, expiresAt: c.now().Add(c.ttl)}
+}
+
+// fetchCatalog GETs the catalog at endpoint, serving it from the cache when possible.
+// A TTL of zero or less disables caching, but concurrent calls are still coalesced.
+func fetchCatalog[T any](ctx context.Context, s *Service, endpoint string) (*T, error) {
+	if s.catalog.ttl > 0 {
+		if v, ok := s.catalog.get(endpoint); ok {
+			if cached, ok := v.(*T); ok {
+				return cached, nil
+			}
+		}
+	}
+
+	// The fetch is shared by every coalesced caller, so it must outlive the context of the first one.
+	// Each caller still gives up on its own context.
+	fetchCtx := context.WithoutCancel(ctx)
+	ch := s.catalog.group.DoChan(endpoint, func() (any, error) {
+		var response T
+		if err := s.client.MakeAPICall(fetchCtx, http.MethodGet, endpoint, nil, &response); err != nil {
+			return nil, err
+		}
+		if s.catalog.ttl > 0 {
+			s.catalog.set(endpoint, &response)
+		}
+		return &response, nil
+	})
+	var res singleflight.Result
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res = <-ch:
+	}
+	if res.Err != nil {
+		return nil, res.Err
+	}
+	response, ok := res.Val.(*T)
+	if !ok {
+		return nil, fmt.Errorf("vm: unexpected catalog type %T for %s", res.Val, endpoint)
+	}
+	return response, nil
+}
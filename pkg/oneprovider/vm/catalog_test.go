@@ -0,0 +1,127 @@
+package vm
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/client"
+)
+
+const testSizesBody = `{"result":"success","response":[{"id":"71","name":"01d20c1-2","type":"General Purpose","cores":"1","ram":"768","hdd":"20"}]}`
+
+func newTestService(t *testing.T, ttl time.Duration, handler http.HandlerFunc) *Service {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c, err := client.NewClient(srv.URL, "api", "client", client.WithRateLimit(0, 0))
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	return NewService(c, ttl)
+}
+
+func countingSizesHandler(calls *atomic.Int32, delay time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		time.Sleep(delay)
+		_, _ = io.WriteString(w, testSizesBody)
+	}
+}
+
+func TestCatalogCache_servesFromCacheUntilExpiry(t *testing.T) {
+	var calls atomic.Int32
+	svc := newTestService(t, time.Minute, countingSizesHandler(&calls, 0))
+
+	now := time.Now()
+	svc.catalog.now = func() time.Time { return now }
+
+	for range 3 {
+		if _, err := svc.GetSizeByName(context.Background(), "01d20c1-2"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected 1 call while cached, got %d", got)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, err := svc.GetSizeByName(context.Background(), "01d20c1-2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected a new call after expiry, got %d", got)
+	}
+}
+
+func TestCatalogCache_disabled(t *testing.T) {
+	var calls atomic.Int32
+	svc := newTestService(t, 0, countingSizesHandler(&calls, 0))
+
+	for range 3 {
+		if _, err := svc.GetSizeByName(context.Background(), "01d20c1-2"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected 3 calls without cache, got %d", got)
+	}
+}
+
+func TestCatalogCache_coalescesConcurrentFetches(t *testing.T) {
+	var calls atomic.Int32
+	svc := newTestService(t, time.Minute, countingSizesHandler(&calls, 50*time.Millisecond))
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := svc.GetSizeByName(context.Background(), "01d20c1-2"); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected concurrent fetches to be coalesced into 1 call, got %d", got)
+	}
+}
+
+func TestCatalogCache_canceledCallerDoesNotFailOthers(t *testing.T) {
+	var calls atomic.Int32
+	svc := newTestService(t, time.Minute, countingSizesHandler(&calls, 100*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := svc.GetSizeByName(ctx, "01d20c1-2")
+		first <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	second := make(chan error, 1)
+	go func() {
+		_, err := svc.GetSizeByName(context.Background(), "01d20c1-2")
+		second <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	if err := <-first; err == nil {
+		t.Errorf("expected the canceled caller to fail")
+	}
+	if err := <-second; err != nil {
+		t.Errorf("unexpected error for the other caller: %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected concurrent fetches to be coalesced into 1 call, got %d", got)
+	}
+}
@@ -1,26 +1,35 @@
 package vm
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/common"
 	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/oneprovider/client"
 )
 
+// ErrNotFound is returned by Get* methods when the requested resource does not exist.
+// It also matches client.ErrNotFound, like the API error returned for unknown VM IDs.
+var ErrNotFound = fmt.Errorf("vm: %w", client.ErrNotFound)
+
 type Service struct {
-	client *client.Client
+	client  *client.Client
+	catalog *catalogCache
 }
 
-func NewService(c *client.Client) *Service {
-	return &Service{client: c}
+// NewService creates a VM service whose templates, locations and sizes
+// catalogs are cached for catalogTTL. A zero or negative TTL disables the cache.
+func NewService(c *client.Client, catalogTTL time.Duration) *Service {
+	return &Service{client: c, catalog: newCatalogCache(catalogTTL)}
 }
 
 func (s *Service) GetTemplateByName(ctx context.Context, name string) (*TemplateReadResponse, error) {
-	var response TemplatesListResponse
-	err := s.client.MakeAPICall(ctx, http.MethodGet, "/vm/templates/", nil, &response)
+	response, err := fetchCatalog[TemplatesListResponse](ctx, s, "/vm/templates/")
 	if err != nil {
 		return nil, fmt.Errorf("vm: get template by name failed: %w", err)
 	}
@@ -28,14 +37,23 @@ func (s *Service) GetTemplateByName(ctx context.Context, name string) (*Template
 		return strings.EqualFold(t.Name, name)
 	})
 	if !found {
-		return nil, fmt.Errorf("vm: template not found for name %s", name)
+		return nil, fmt.Errorf("vm: template not found for name %s: %w", name, ErrNotFound)
 	}
 	return &tpl, nil
 }
 
+// ListTemplates returns every VM template, as ordered by the API.
+func (s *Service) ListTemplates(ctx context.Context) ([]TemplateReadResponse, error) {
+	response, err := fetchCatalog[TemplatesListResponse](ctx, s, "/vm/templates/")
+	if err != nil {
+		return nil, fmt.Errorf("vm: list templates failed: %w", err)
+	}
+	// The response may be cached, hand out a copy.
+	return slices.Clone(response.Templates), nil
+}
+
 func (s *Service) GetLocationByCity(ctx context.Context, city string) (*LocationReadResponse, error) {
-	var response LocationsListResponse
-	err := s.client.MakeAPICall(ctx, http.MethodGet, "/vm/locations", nil, &response)
+	response, err := fetchCatalog[LocationsListResponse](ctx, s, "/vm/locations")
 	if err != nil {
 		return nil, fmt.Errorf("vm: get location by city name failed: %w", err)
 	}
@@ -48,7 +66,25 @@ func (s *Service) GetLocationByCity(ctx context.Context, city string) (*Location
 			return &location, nil
 		}
 	}
-	return nil, fmt.Errorf("vm: location not found for city %s", city)
+	return nil, fmt.Errorf("vm: location not found for city %s: %w", city, ErrNotFound)
+}
+
+// ListLocations returns the locations of every region, sorted by region then city.
+func (s *Service) ListLocations(ctx context.Context) ([]LocationReadResponse, error) {
+	response, err := fetchCatalog[LocationsListResponse](ctx, s, "/vm/locations")
+	if err != nil {
+		return nil, fmt.Errorf("vm: list locations failed: %w", err)
+	}
+
+	// Appending copies the cached response, so sorting it is safe.
+	var locations []LocationReadResponse
+	for _, regions := range response.Response {
+		locations = append(locations, regions...)
+	}
+	slices.SortFunc(locations, func(a, b LocationReadResponse) int {
+		return cmp.Or(cmp.Compare(a.Region, b.Region), cmp.Compare(a.City, b.City))
+	})
+	return locations, nil
 }
 
 func (s *Service) GetInstanceByID(ctx context.Context, id string) (*InstanceReadResponse, error) {
@@ -61,6 +97,17 @@ func (s *Service) GetInstanceByID(ctx context.Context, id string) (*InstanceRead
 	return &response, nil
 }
 
+// ListInstances returns every VM of the account.
+func (s *Service) ListInstances(ctx context.Context) (*InstancesListResponse, error) {
+	var response InstancesListResponse
+	err := s.client.MakeAPICall(ctx, http.MethodGet, "/vm/listing", nil, &response)
+	if err != nil {
+		return nil, fmt.Errorf("vm: list instances failed: %w", err)
+	}
+
+	return &response, nil
+}
+
 func (s *Service) CreateInstance(ctx context.Context, req *InstanceCreateRequest) (*InstanceCreateResponse, error) {
 	var response InstanceCreateResponse
 
@@ -80,6 +127,63 @@ func (s *Service) UpdateInstanceHostname(ctx context.Context, req *InstanceHostn
 	return nil
 }
 
+// ReinstallInstance reinstalls the VM with another template, keeping its ID and IP address.
+// The returned password replaces the previous root password.
+func (s *Service) ReinstallInstance(ctx context.Context, req *InstanceReinstallRequest) (*InstanceReinstallResponse, error) {
+	var response InstanceReinstallResponse
+
+	err := s.client.MakeAPICall(ctx, http.MethodPost, "/vm/reinstall", strings.NewReader(req.UrlValues().Encode()), &response)
+	if err != nil {
+		return nil, fmt.Errorf("vm: reinstall instance failed: %w", err)
+	}
+
+	return &response, nil
+}
+
+// ResetInstancePassword generates a new root password for the VM.
+// The API never returns the current password, so reading it means resetting it.
+func (s *Service) ResetInstancePassword(ctx context.Context, req *InstancePasswordResetRequest) (*InstancePasswordResetResponse, error) {
+	var response InstancePasswordResetResponse
+
+	err := s.client.MakeAPICall(ctx, http.MethodPost, "/vm/password/reset", strings.NewReader(req.UrlValues().Encode()), &response)
+	if err != nil {
+		return nil, fmt.Errorf("vm: reset instance password failed: %w", err)
+	}
+
+	return &response, nil
+}
+
+// AttachInstanceSSHKey authorizes an SSH key of the account on the VM without reinstalling it.
+// The /vm/sshkey/attach endpoint is not in the OneProvider API documentation, it follows the
+// /vm/sshkey/new naming and is only checked against the fake API of the oneprovidertest package.
+func (s *Service) AttachInstanceSSHKey(ctx context.Context, req *InstanceSSHKeyRequest) error {
+	err := s.client.MakeAPICall(ctx, http.MethodPost, "/vm/sshkey/attach", strings.NewReader(req.UrlValues().Encode()), nil)
+	if err != nil {
+		return fmt.Errorf("vm: attach instance ssh key failed: %w", err)
+	}
+	return nil
+}
+
+// DetachInstanceSSHKey removes an SSH key from the keys authorized on the VM.
+// Like /vm/sshkey/attach, the /vm/sshkey/detach endpoint is not in the OneProvider API documentation.
+func (s *Service) DetachInstanceSSHKey(ctx context.Context, req *InstanceSSHKeyRequest) error {
+	err := s.client.MakeAPICall(ctx, http.MethodPost, "/vm/sshkey/detach", strings.NewReader(req.UrlValues().Encode()), nil)
+	if err != nil {
+		return fmt.Errorf("vm: detach instance ssh key failed: %w", err)
+	}
+	return nil
+}
+
+// ResizeInstance changes the instance size of the VM in place.
+// The API may refuse to resize a running VM, which must then be stopped first.
+func (s *Service) ResizeInstance(ctx context.Context, req *InstanceResizeRequest) error {
+	err := s.client.MakeAPICall(ctx, http.MethodPost, "/vm/resize", strings.NewReader(req.UrlValues().Encode()), nil)
+	if err != nil {
+		return fmt.Errorf("vm: resize instance failed: %w", err)
+	}
+	return nil
+}
+
 func (s *Service) DestroyInstance(ctx context.Context, req *InstanceDestroyRequest) error {
 	err := s.client.MakeAPICall(ctx, http.MethodPost, "/vm/destroy", strings.NewReader(req.UrlValues().Encode()), nil)
 	if err != nil {
@@ -88,9 +192,29 @@ func (s *Service) DestroyInstance(ctx context.Context, req *InstanceDestroyReque
 	return nil
 }
 
+func (s *Service) StartInstance(ctx context.Context, id string) error {
+	return s.instanceAction(ctx, id, InstanceActionStart)
+}
+
+func (s *Service) StopInstance(ctx context.Context, id string) error {
+	return s.instanceAction(ctx, id, InstanceActionStop)
+}
+
+func (s *Service) RebootInstance(ctx context.Context, id string) error {
+	return s.instanceAction(ctx, id, InstanceActionReboot)
+}
+
+func (s *Service) instanceAction(ctx context.Context, id, action string) error {
+	req := &InstanceActionRequest{VmId: id, Action: action}
+	err := s.client.MakeAPICall(ctx, http.MethodPost, "/vm/action", strings.NewReader(req.UrlValues().Encode()), nil)
+	if err != nil {
+		return fmt.Errorf("vm: %s instance failed: %w", action, err)
+	}
+	return nil
+}
+
 func (s *Service) GetSizeByName(ctx context.Context, name string) (*SizeReadResponse, error) {
-	var response SizesListResponse
-	err := s.client.MakeAPICall(ctx, http.MethodGet, "/vm/sizes", nil, &response)
+	response, err := fetchCatalog[SizesListResponse](ctx, s, "/vm/sizes")
 	if err != nil {
 		return nil, fmt.Errorf("vm: get size by name failed: %w", err)
 	}
@@ -101,5 +225,45 @@ func (s *Service) GetSizeByName(ctx context.Context, name string) (*SizeReadResp
 		return &size, nil
 	}
 
-	return nil, fmt.Errorf("vm: size not found for name %s", name)
+	return nil, fmt.Errorf("vm: size not found for name %s: %w", name, ErrNotFound)
+}
+
+// ListSizes returns every VM size, as ordered by the API.
+func (s *Service) ListSizes(ctx context.Context) ([]SizeReadResponse, error) {
+	response, err := fetchCatalog[SizesListResponse](ctx, s, "/vm/sizes")
+	if err != nil {
+		return nil, fmt.Errorf("vm: list sizes failed: %w", err)
+	}
+	// The response may be cached, hand out a copy.
+	return slices.Clone(response.Response), nil
+}
+
+func (s *Service) GetSizeByID(ctx context.Context, id string) (*SizeReadResponse, error) {
+	response, err := fetchCatalog[SizesListResponse](ctx, s, "/vm/sizes")
+	if err != nil {
+		return nil, fmt.Errorf("vm: get size by id failed: %w", err)
+	}
+
+	size, found := common.FindElement(response.Response, func(s SizeReadResponse) bool { return s.Id == id })
+	if !found {
+		return nil, fmt.Errorf("vm: size not found for id %s: %w", id, ErrNotFound)
+	}
+	return &size, nil
+}
+
+func (s *Service) GetLocationByID(ctx context.Context, id string) (*LocationReadResponse, error) {
+	response, err := fetchCatalog[LocationsListResponse](ctx, s, "/vm/locations")
+	if err != nil {
+		return nil, fmt.Errorf("vm: get location by id failed: %w", err)
+	}
+
+	findIDFn := func(l LocationReadResponse) bool { return l.Id == id }
+
+	for _, regions := range response.Response {
+		location, found := common.FindElement(regions, findIDFn)
+		if found {
+			return &location, nil
+		}
+	}
+	return nil, fmt.Errorf("vm: location not found for id %s: %w", id, ErrNotFound)
 }
@@ -0,0 +1,117 @@
+package vm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/MadJlzz/terraform-provider-oneprovider/pkg/common"
+)
+
+const (
+	SnapshotStatusCreating  = "creating"
+	SnapshotStatusAvailable = "available"
+)
+
+type SnapshotsListResponse struct {
+	Response []SnapshotReadResponse `json:"response"`
+}
+
+type SnapshotReadResponse struct {
+	Id        string `json:"id"`
+	VmId      string `json:"vm_id"`
+	Name      string `json:"name"`
+	Size      int    `json:"size"`
+	Status    string `json:"status"`
+	CreatedAt string `json:"created_at"`
+}
+
+type SnapshotCreateRequest struct {
+	VmId string `json:"vm_id"`
+	Name string `json:"name"`
+}
+
+func (v *SnapshotCreateRequest) UrlValues() url.Values {
+	return url.Values{
+		"vm_id": {v.VmId},
+		"name":  {v.Name},
+	}
+}
+
+type SnapshotCreateResponse struct {
+	Response struct {
+		Message string `json:"message"`
+		Id      string `json:"id"`
+	} `json:"response"`
+}
+
+type SnapshotRestoreRequest struct {
+	VmId       string `json:"vm_id"`
+	SnapshotId string `json:"snapshot_id"`
+}
+
+func (v *SnapshotRestoreRequest) UrlValues() url.Values {
+	return url.Values{
+		"vm_id":       {v.VmId},
+		"snapshot_id": {v.SnapshotId},
+	}
+}
+
+// CreateSnapshot starts a snapshot of the VM disk. The snapshot is usable once
+// its status is SnapshotStatusAvailable.
+func (s *Service) CreateSnapshot(ctx context.Context, req *SnapshotCreateRequest) (*SnapshotCreateResponse, error) {
+	var response SnapshotCreateResponse
+
+	err := s.client.MakeAPICall(ctx, http.MethodPost, "/vm/snapshot/create", strings.NewReader(req.UrlValues().Encode()), &response)
+	if err != nil {
+		return nil, fmt.Errorf("vm: create snapshot failed: %w", err)
+	}
+
+	return &response, nil
+}
+
+// ListSnapshots returns the snapshots of every VM of the account.
+func (s *Service) ListSnapshots(ctx context.Context) ([]SnapshotReadResponse, error) {
+	var response SnapshotsListResponse
+
+	err := s.client.MakeAPICall(ctx, http.MethodGet, "/vm/snapshot/list", nil, &response)
+	if err != nil {
+		return nil, fmt.Errorf("vm: list snapshots failed: %w", err)
+	}
+
+	return response.Response, nil
+}
+
+func (s *Service) GetSnapshotByID(ctx context.Context, id string) (*SnapshotReadResponse, error) {
+	snapshots, err := s.ListSnapshots(ctx)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, found := common.FindElement(snapshots, func(sn SnapshotReadResponse) bool { return sn.Id == id })
+	if !found {
+		return nil, fmt.Errorf("vm: snapshot not found for id %s: %w", id, ErrNotFound)
+	}
+	return &snapshot, nil
+}
+
+func (s *Service) DeleteSnapshot(ctx context.Context, id string) error {
+	data := url.Values{"snapshot_id": {id}}
+
+	err := s.client.MakeAPICall(ctx, http.MethodPost, "/vm/snapshot/delete", strings.NewReader(data.Encode()), nil)
+	if err != nil {
+		return fmt.Errorf("vm: delete snapshot failed: %w", err)
+	}
+	return nil
+}
+
+// RestoreSnapshot rolls the VM disk back to one of its snapshots. The VM reports
+// server_install while the snapshot is being restored.
+func (s *Service) RestoreSnapshot(ctx context.Context, req *SnapshotRestoreRequest) error {
+	err := s.client.MakeAPICall(ctx, http.MethodPost, "/vm/snapshot/restore", strings.NewReader(req.UrlValues().Encode()), nil)
+	if err != nil {
+		return fmt.Errorf("vm: restore snapshot failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package vm
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+// templateVersionRegexp matches the first dotted number standing as a word
+// of a template name, e.g. 24.04.3 in "Ubuntu 24.04.3 64bits". Numbers glued
+// to letters, like the 64 of "64bits", are not versions.
+var templateVersionRegexp = regexp.MustCompile(`\b\d+(\.\d+)*\b`)
+
+// Family returns the lower-cased OS family of the template, e.g. "debian".
+func (t TemplateReadResponse) Family() string {
+	return strings.ToLower(t.Display.Name)
+}
+
+// Version parses the OS version out of the template name.
+func (t TemplateReadResponse) Version() (*version.Version, error) {
+	raw := templateVersionRegexp.FindString(t.Name)
+	if raw == "" {
+		return nil, fmt.Errorf("vm: no version in template name %q", t.Name)
+	}
+	return version.NewVersion(raw)
+}
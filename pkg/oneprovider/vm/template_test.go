@@ -0,0 +1,43 @@
+package vm
+
+import "testing"
+
+func TestTemplateReadResponse_Version(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "Ubuntu 24.04.3 64bits", want: "24.4.3"},
+		{name: "Debian 12 64bits", want: "12.0.0"},
+		{name: "AlmaLinux 8.10 64bits", want: "8.10.0"},
+		{name: "CentOS 7 x86_64", want: "7.0.0"},
+		{name: "Debian 64bits", wantErr: true},
+		{name: "FreeBSD", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TemplateReadResponse{Name: tt.name}.Version()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("expected version %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTemplateReadResponse_Family(t *testing.T) {
+	var tpl TemplateReadResponse
+	tpl.Display.Name = "Debian"
+	if got := tpl.Family(); got != "debian" {
+		t.Errorf("expected family debian, got %s", got)
+	}
+}
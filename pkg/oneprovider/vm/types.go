@@ -39,15 +39,31 @@ type LocationReadResponse struct {
 	} `json:"available_ips"`
 }
 
+// SupportsIPv4 reports whether IPv4 addresses can be used in the location.
+func (l *LocationReadResponse) SupportsIPv4() bool {
+	return l.AvailableIPs.IPv4 == "1"
+}
+
+// SupportsIPv6 reports whether IPv6 addresses can be used in the location.
+func (l *LocationReadResponse) SupportsIPv6() bool {
+	return l.AvailableIPs.IPv6 == "1"
+}
+
 type InstanceReadResponse struct {
 	Response struct {
 		ServerInstall bool `json:"server_install"`
 		ServerInfo    struct {
 			IpAddress string `json:"ipaddress"`
-			Hostname  string `json:"hostname"`
-			City      string `json:"city"`
-			Plan      string `json:"plan"`
-			Template  string `json:"template"`
+			// IPv6Address and IPv6Prefix are empty unless IPv6 is enabled on the VM. Their names
+			// follow ipaddress but were not checked against a real answer, see testdata/vm_info_synthetic.json.
+			IPv6Address string `json:"ipv6address"`
+			IPv6Prefix  string `json:"ipv6prefix"`
+			Hostname    string `json:"hostname"`
+			City        string `json:"city"`
+			Plan        string `json:"plan"`
+			Template    string `json:"template"`
+			// SshKeys is nil when the API doesn't report the SSH keys authorized on the VM.
+			SshKeys []string `json:"ssh_keys"`
 		} `json:"server_info"`
 		ServerState struct {
 			Status string `json:"status"`
@@ -56,24 +72,57 @@ type InstanceReadResponse struct {
 	} `json:"response"`
 }
 
+type InstancesListResponse struct {
+	Response []InstanceSummary `json:"response"`
+}
+
+// InstanceSummary is a VM as listed by /vm/listing.
+type InstanceSummary struct {
+	Id        string `json:"id"`
+	Hostname  string `json:"hostname"`
+	IpAddress string `json:"ipaddress"`
+	City      string `json:"city"`
+	Plan      string `json:"plan"`
+	Template  string `json:"template"`
+	Status    string `json:"status"`
+	State     string `json:"state"`
+}
+
 type InstanceCreateRequest struct {
 	LocationId     int      `json:"location_id"`
 	InstanceSizeId int      `json:"instance_size"`
 	TemplateId     string   `json:"template"`
 	Hostname       string   `json:"hostname"`
 	SshKeys        []string `json:"ssh_keys"`
+	// UserData is base64 encoded, see EncodeUserData.
+	UserData string `json:"user_data"`
+	// SnapshotId clones the disk of a snapshot instead of installing TemplateId.
+	SnapshotId string `json:"snapshot"`
+	// EnableIPv6 routes an IPv6 prefix to the VM, the location must support IPv6.
+	EnableIPv6 bool `json:"ipv6"`
 }
 
 func (v *InstanceCreateRequest) UrlValues() url.Values {
 	urlValues := url.Values{
 		"location_id":   {strconv.Itoa(v.LocationId)},
 		"instance_size": {strconv.Itoa(v.InstanceSizeId)},
-		"template":      {v.TemplateId},
 		"hostname":      {v.Hostname},
 	}
+	if v.TemplateId != "" {
+		urlValues.Set("template", v.TemplateId)
+	}
 	for idx, key := range v.SshKeys {
 		urlValues.Add(fmt.Sprintf("ssh_keys[%d]", idx), key)
 	}
+	if v.UserData != "" {
+		urlValues.Set("user_data", v.UserData)
+	}
+	if v.SnapshotId != "" {
+		urlValues.Set("snapshot", v.SnapshotId)
+	}
+	if v.EnableIPv6 {
+		urlValues.Set("ipv6", strconv.FormatBool(v.EnableIPv6))
+	}
 	return urlValues
 }
 
@@ -111,6 +160,96 @@ func (v *InstanceDestroyRequest) UrlValues() url.Values {
 	}
 }
 
+type InstanceReinstallRequest struct {
+	VmId       string   `json:"vm_id"`
+	TemplateId string   `json:"template"`
+	SshKeys    []string `json:"ssh_keys"`
+}
+
+func (v *InstanceReinstallRequest) UrlValues() url.Values {
+	urlValues := url.Values{
+		"vm_id":    {v.VmId},
+		"template": {v.TemplateId},
+	}
+	for idx, key := range v.SshKeys {
+		urlValues.Add(fmt.Sprintf("ssh_keys[%d]", idx), key)
+	}
+	return urlValues
+}
+
+type InstanceReinstallResponse struct {
+	Response struct {
+		Message  string `json:"message"`
+		Password string `json:"password"`
+	} `json:"response"`
+}
+
+type InstanceSSHKeyRequest struct {
+	VmId   string `json:"vm_id"`
+	SshKey string `json:"ssh_key"`
+}
+
+func (v *InstanceSSHKeyRequest) UrlValues() url.Values {
+	return url.Values{
+		"vm_id":   {v.VmId},
+		"ssh_key": {v.SshKey},
+	}
+}
+
+type InstancePasswordResetRequest struct {
+	VmId string `json:"vm_id"`
+}
+
+func (v *InstancePasswordResetRequest) UrlValues() url.Values {
+	return url.Values{
+		"vm_id": {v.VmId},
+	}
+}
+
+type InstancePasswordResetResponse struct {
+	Response struct {
+		Message  string `json:"message"`
+		Password string `json:"password"`
+	} `json:"response"`
+}
+
+type InstanceResizeRequest struct {
+	VmId           string `json:"vm_id"`
+	InstanceSizeId int    `json:"instance_size"`
+}
+
+func (v *InstanceResizeRequest) UrlValues() url.Values {
+	return url.Values{
+		"vm_id":         {v.VmId},
+		"instance_size": {strconv.Itoa(v.InstanceSizeId)},
+	}
+}
+
+// Actions accepted by /vm/action.
+const (
+	InstanceActionStart  = "start"
+	InstanceActionStop   = "stop"
+	InstanceActionReboot = "reboot"
+)
+
+// Values reported in InstanceReadResponse ServerState.State.
+const (
+	InstanceStateOnline  = "online"
+	InstanceStateOffline = "offline"
+)
+
+type InstanceActionRequest struct {
+	VmId   string `json:"vm_id"`
+	Action string `json:"action"`
+}
+
+func (v *InstanceActionRequest) UrlValues() url.Values {
+	return url.Values{
+		"vm_id":  {v.VmId},
+		"action": {v.Action},
+	}
+}
+
 type SizesListResponse struct {
 	Response []SizeReadResponse `json:"response"`
 }
@@ -0,0 +1,31 @@
+package vm
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+// TestInstanceReadResponse_synthetic pins the field names assumed for /vm/info. The fixture is
+// hand-written, not captured from the real API: replace it once a real answer is available.
+func TestInstanceReadResponse_synthetic(t *testing.T) {
+	b, err := os.ReadFile("testdata/vm_info_synthetic.json")
+	if err != nil {
+		t.Fatalf("unexpected error reading fixture: %v", err)
+	}
+	var info InstanceReadResponse
+	if err := json.Unmarshal(b, &info); err != nil {
+		t.Fatalf("unexpected error decoding fixture: %v", err)
+	}
+
+	got := info.Response.ServerInfo
+	if got.IpAddress != "203.0.113.10" || got.IPv6Address != "2001:db8:10::2" || got.IPv6Prefix != "2001:db8:10::/64" {
+		t.Errorf("expected the addresses of the fixture, got %+v", got)
+	}
+	if got.SshKeys != nil {
+		t.Errorf("expected no SSH keys, got %v", got.SshKeys)
+	}
+	if info.Response.ServerState.State != InstanceStateOnline {
+		t.Errorf("expected state %s, got %s", InstanceStateOnline, info.Response.ServerState.State)
+	}
+}
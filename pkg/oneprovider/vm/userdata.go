@@ -0,0 +1,44 @@
+package vm
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+)
+
+// MaxUserDataSize is the largest user data accepted by the API, before base64 encoding.
+const MaxUserDataSize = 64 * 1024
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// EncodeUserData returns data encoded for InstanceCreateRequest.UserData.
+// Data larger than MaxUserDataSize is gzip compressed, which cloud-init handles
+// on first boot. Data that is already gzip compressed is checked but sent as is.
+func EncodeUserData(data []byte) (string, error) {
+	if bytes.HasPrefix(data, gzipMagic) {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return "", fmt.Errorf("vm: malformed gzip user data: %w", err)
+		}
+		if _, err = io.Copy(io.Discard, zr); err != nil {
+			return "", fmt.Errorf("vm: malformed gzip user data: %w", err)
+		}
+	} else if len(data) > MaxUserDataSize {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(data); err != nil {
+			return "", fmt.Errorf("vm: compress user data failed: %w", err)
+		}
+		if err := zw.Close(); err != nil {
+			return "", fmt.Errorf("vm: compress user data failed: %w", err)
+		}
+		data = buf.Bytes()
+	}
+
+	if len(data) > MaxUserDataSize {
+		return "", fmt.Errorf("vm: user data is %d bytes once compressed, the limit is %d bytes", len(data), MaxUserDataSize)
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
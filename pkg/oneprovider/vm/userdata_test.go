@@ -0,0 +1,55 @@
+package vm
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestEncodeUserData(t *testing.T) {
+	script := []byte("#cloud-config\npackages:\n  - nginx\n")
+	got, err := EncodeUserData(script)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := base64.StdEncoding.EncodeToString(script); got != want {
+		t.Errorf("expected small user data to be sent as is, got %s", got)
+	}
+
+	large := []byte("#!/bin/sh\n" + strings.Repeat("echo hello\n", MaxUserDataSize/10))
+	got, err = EncodeUserData(large)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	compressed, err := base64.StdEncoding.DecodeString(got)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatalf("expected large user data to be gzip compressed: %v", err)
+	}
+	if decompressed, _ := io.ReadAll(zr); !bytes.Equal(decompressed, large) {
+		t.Errorf("expected compressed user data to decompress to the original one")
+	}
+
+	// Already compressed user data is sent as is.
+	if again, err := EncodeUserData(compressed); err != nil || again != got {
+		t.Errorf("expected gzip user data to be sent as is, got %v", err)
+	}
+
+	random := make([]byte, 2*MaxUserDataSize)
+	if _, err = rand.Read(random); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err = EncodeUserData(random); err == nil {
+		t.Errorf("expected an error for incompressible user data above the limit")
+	}
+	if _, err = EncodeUserData([]byte{0x1f, 0x8b, 0x00}); err == nil {
+		t.Errorf("expected an error for malformed gzip user data")
+	}
+}